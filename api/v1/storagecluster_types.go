@@ -79,6 +79,40 @@ type StorageClusterSpec struct {
 	// AllowRemoteStorageConsumers Indicates that the OCS cluster should deploy the needed
 	// components to enable connections from remote consumers.
 	AllowRemoteStorageConsumers bool `json:"allowRemoteStorageConsumers,omitempty"`
+
+	// DeviceClassProfiles specifies the OSD resources, memory target, placement
+	// and tuning per device class. They apply to the StorageDeviceSets of the
+	// matching device class that do not set these fields themselves.
+	// +optional
+	DeviceClassProfiles []DeviceClassProfile `json:"deviceClassProfiles,omitempty"`
+}
+
+// DeviceClassProfile defines the OSD configuration for the StorageDeviceSets
+// of a given device class
+type DeviceClassProfile struct {
+	// DeviceClass is the CRUSH device class the profile applies to. It is
+	// matched against the DeviceClass of a StorageDeviceSet or, if empty,
+	// against its DeviceType.
+	DeviceClass string `json:"deviceClass"`
+
+	// Resources are the OSD resource requirements for the device class
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// OSDMemoryTarget is the osd_memory_target of the OSDs of the device
+	// class. Ceph derives it from the OSD memory limit, so the memory
+	// request and limit of the OSDs are sized for it. It can not be set
+	// together with a memory request or limit in Resources.
+	// +optional
+	OSDMemoryTarget *resource.Quantity `json:"osdMemoryTarget,omitempty"`
+
+	// Placement is the OSD placement for the device class
+	// +optional
+	Placement *rookCephv1.Placement `json:"placement,omitempty"`
+
+	// Config is the OSD tuning for the device class
+	// +optional
+	Config *StorageDeviceSetConfig `json:"config,omitempty"`
 }
 
 // KeyManagementServiceSpec provides a way to enable KMS
@@ -408,6 +442,10 @@ const (
 	// ConditionExternalClusterConnecting type indicates that rook is still trying for
 	// an external connection
 	ConditionExternalClusterConnecting conditionsv1.ConditionType = "ExternalClusterConnecting"
+
+	// ConditionKMSConnected condition type indicates whether the KMS provider
+	// configured for encryption is valid and reachable
+	ConditionKMSConnected conditionsv1.ConditionType = "KMSConnected"
 )
 
 // List of constants to show different different reconciliation messages and statuses.
@@ -64,6 +64,41 @@ func (in *ComponentImageStatus) DeepCopy() *ComponentImageStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceClassProfile) DeepCopyInto(out *DeviceClassProfile) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OSDMemoryTarget != nil {
+		in, out := &in.OSDMemoryTarget, &out.OSDMemoryTarget
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(ceph_rook_iov1.Placement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(StorageDeviceSetConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceClassProfile.
+func (in *DeviceClassProfile) DeepCopy() *DeviceClassProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceClassProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EncryptionSpec) DeepCopyInto(out *EncryptionSpec) {
 	*out = *in
@@ -630,6 +665,13 @@ func (in *StorageClusterSpec) DeepCopyInto(out *StorageClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DeviceClassProfiles != nil {
+		in, out := &in.DeviceClassProfiles, &out.DeviceClassProfiles
+		*out = make([]DeviceClassProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClusterSpec.
@@ -33,12 +33,22 @@ const (
 	StorageConsumerStateDeleting StorageConsumerState = "Deleting"
 	// StorageConsumerStateFailed represents Failed state of StorageConsumer
 	StorageConsumerStateFailed StorageConsumerState = "Failed"
+	// StorageConsumerStateRevoked represents Revoked state of StorageConsumer
+	StorageConsumerStateRevoked StorageConsumerState = "Revoked"
 )
 
 // StorageConsumerSpec defines the desired state of StorageConsumer
 type StorageConsumerSpec struct {
 	// Capacity is the total quota size allocated to a consumer.
 	Capacity resource.Quantity `json:"capacity"`
+	// CephClientKeysGeneration is increased to request new keys for the ceph
+	// clients of the consumer. The previous keys are revoked.
+	// +optional
+	CephClientKeysGeneration int64 `json:"cephClientKeysGeneration,omitempty"`
+	// Revoked revokes the access of the consumer. Its ceph clients are deleted
+	// while the storage resources of the consumer are kept.
+	// +optional
+	Revoked bool `json:"revoked,omitempty"`
 }
 
 // CephResourcesSpec hold details of created ceph resources required for external storage
@@ -61,6 +71,8 @@ type StorageConsumerStatus struct {
 	GrantedCapacity resource.Quantity `json:"grantedCapacity,omitempty"`
 	// CephResources provide details of created ceph resources required for external storage
 	CephResources []*CephResourcesSpec `json:"cephResources,omitempty"`
+	// CephClientKeysGeneration is the generation of the keys of the ceph clients of the consumer
+	CephClientKeysGeneration int64 `json:"cephClientKeysGeneration,omitempty"`
 }
 
 //+kubebuilder:object:root=true
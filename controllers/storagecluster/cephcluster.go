@@ -3,6 +3,7 @@ package storagecluster
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"reflect"
 	"strconv"
@@ -135,17 +136,22 @@ func (obj *ocsCephCluster) ensureCreated(r *StorageClusterReconciler, sc *ocsv1.
 		if sc.Spec.Encryption.Enable || sc.Spec.Encryption.ClusterWide {
 			kmsConfigMap, err := getKMSConfigMap(KMSConfigMapName, sc, r.Client)
 			if err != nil {
+				if errors.IsNotFound(err) {
+					clearKMSConnectedStatus(sc)
+				}
 				r.Log.Error(err, "Failed to procure KMS ConfigMap.", "KMSConfigMap", klog.KRef(sc.Namespace, KMSConfigMapName))
 				return reconcile.Result{}, err
 			}
 			if kmsConfigMap != nil {
-				if err = reachKMSProvider(kmsConfigMap); err != nil {
-					r.Log.Error(err, "Address provided in KMS ConfigMap is not reachable.", "KMSConfigMap", klog.KRef(kmsConfigMap.Namespace, kmsConfigMap.Name))
+				if err = r.checkKMSConnection(sc, kmsConfigMap); err != nil {
 					return reconcile.Result{}, err
 				}
+			} else {
+				clearKMSConnectedStatus(sc)
 			}
 			cephCluster = newCephCluster(sc, r.images.Ceph, r.nodeCount, r.serverVersion, kmsConfigMap, r.Log)
 		} else {
+			clearKMSConnectedStatus(sc)
 			cephCluster = newCephCluster(sc, r.images.Ceph, r.nodeCount, r.serverVersion, nil, r.Log)
 		}
 	}
@@ -424,9 +430,9 @@ func newCephCluster(sc *ocsv1.StorageCluster, cephImage string, nodeCount int, s
 				// Secret is created by UI in "openshift-storage" namespace
 				cephCluster.Spec.Security.KeyManagementService.TokenSecretName = KMSTokenSecretName
 			}
-		} else if kmsConfigMap.Data["KMS_PROVIDER"] == IbmKeyProtectKMSProvider {
+		} else if provider, ok := kmsProviders[kmsConfigMap.Data["KMS_PROVIDER"]]; ok && provider.secretNameKey != "" {
 			// Secret is created by UI in "openshift-storage" namespace
-			cephCluster.Spec.Security.KeyManagementService.TokenSecretName = kmsConfigMap.Data["IBM_KP_SECRET_NAME"]
+			cephCluster.Spec.Security.KeyManagementService.TokenSecretName = kmsConfigMap.Data[provider.secretNameKey]
 		}
 		cephCluster.Spec.Security.KeyManagementService.ConnectionDetails = kmsConfigMap.Data
 	}
@@ -600,6 +606,8 @@ func newStorageClassDeviceSets(sc *ocsv1.StorageCluster, serverVersion *version.
 	supportTSC := serverVersion.Major >= defaults.KubeMajorTopologySpreadConstraints && serverVersion.Minor >= defaults.KubeMinorTopologySpreadConstraints
 
 	for _, ds := range storageDeviceSets {
+		applyDeviceClassProfile(&ds, getDeviceClassProfile(sc, &ds))
+
 		resources := ds.Resources
 		if resources.Requests == nil && resources.Limits == nil {
 			resources = defaults.DaemonResources["osd"]
@@ -746,6 +754,76 @@ func newStorageClassDeviceSets(sc *ocsv1.StorageCluster, serverVersion *version.
 	return storageClassDeviceSets
 }
 
+// deviceClassOf returns the CRUSH device class of the given StorageDeviceSet
+func deviceClassOf(ds *ocsv1.StorageDeviceSet) string {
+	if ds.DeviceClass != "" {
+		return ds.DeviceClass
+	}
+	return ds.DeviceType
+}
+
+// getDeviceClassProfile returns the DeviceClassProfile matching the device
+// class of the given StorageDeviceSet, or nil if there is none
+func getDeviceClassProfile(sc *ocsv1.StorageCluster, ds *ocsv1.StorageDeviceSet) *ocsv1.DeviceClassProfile {
+	deviceClass := deviceClassOf(ds)
+	if deviceClass == "" {
+		return nil
+	}
+	for i := range sc.Spec.DeviceClassProfiles {
+		if strings.EqualFold(sc.Spec.DeviceClassProfiles[i].DeviceClass, deviceClass) {
+			return &sc.Spec.DeviceClassProfiles[i]
+		}
+	}
+	return nil
+}
+
+// applyDeviceClassProfile sets the resources, placement and tuning config
+// of the StorageDeviceSet from the profile, unless already set on the
+// StorageDeviceSet itself
+func applyDeviceClassProfile(ds *ocsv1.StorageDeviceSet, profile *ocsv1.DeviceClassProfile) {
+	if profile == nil {
+		return
+	}
+
+	if ds.Resources.Requests == nil && ds.Resources.Limits == nil {
+		resources := defaults.DaemonResources["osd"]
+		if profile.Resources != nil {
+			resources = *profile.Resources
+		}
+		resources = *resources.DeepCopy()
+		if profile.OSDMemoryTarget != nil {
+			memory := osdMemoryForTarget(*profile.OSDMemoryTarget)
+			if resources.Requests == nil {
+				resources.Requests = corev1.ResourceList{}
+			}
+			if resources.Limits == nil {
+				resources.Limits = corev1.ResourceList{}
+			}
+			resources.Requests[corev1.ResourceMemory] = memory
+			resources.Limits[corev1.ResourceMemory] = memory
+		}
+		ds.Resources = resources
+	}
+
+	noPlacement := ds.Placement.NodeAffinity == nil && ds.Placement.PodAffinity == nil &&
+		ds.Placement.PodAntiAffinity == nil && ds.Placement.TopologySpreadConstraints == nil
+	if noPlacement && profile.Placement != nil {
+		ds.Placement = *profile.Placement.DeepCopy()
+	}
+
+	if profile.Config != nil && !ds.Config.TuneSlowDeviceClass && !ds.Config.TuneFastDeviceClass {
+		ds.Config = *profile.Config
+	}
+}
+
+// osdMemoryForTarget returns the OSD memory limit for which Ceph derives the
+// given osd_memory_target, as set by osd_memory_target_cgroup_limit_ratio in
+// the rook config override
+func osdMemoryForTarget(target resource.Quantity) resource.Quantity {
+	memory := int64(math.Ceil(float64(target.Value()) / osdMemoryTargetCgroupLimitRatio))
+	return *resource.NewQuantity(memory, resource.BinarySI)
+}
+
 func countAndReplicaOf(ds *ocsv1.StorageDeviceSet) (int, int) {
 	count := ds.Count
 	replica := ds.Replica
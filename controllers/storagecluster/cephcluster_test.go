@@ -21,6 +21,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/version"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 func TestEnsureCephCluster(t *testing.T) {
@@ -462,7 +463,7 @@ func createDummyKMSConfigMap(kmsProvider, kmsAddr string, kmsAuthMethod string)
 		if kmsAuthMethod == VaultSAAuthMethod {
 			cm.Data["VAULT_AUTH_METHOD"] = VaultSAAuthMethod
 		}
-		cm.Data[kmsProviderAddressKeyMap[kmsProvider]] = kmsAddr
+		cm.Data[kmsProviders[kmsProvider].addressKey] = kmsAddr
 		cm.Data["VAULT_BACKEND_PATH"] = "ocs"
 		cm.Data["VAULT_NAMESPACE"] = "my-ocs-namespace"
 	case IbmKeyProtectKMSProvider:
@@ -572,9 +573,49 @@ func assertCephClusterKMSConfiguration(t *testing.T, kmsArgs struct {
 		if kmsArgs.authMethod == VaultTokenAuthMethod {
 			assert.Equal(t, KMSTokenSecretName, cephCluster.Spec.Security.KeyManagementService.TokenSecretName, "Failed: %q. Expected the token-names tobe same", kmsArgs.testLabel)
 		}
+		if provider, ok := kmsProviders[kmsArgs.kmsProvider]; ok && provider.secretNameKey != "" {
+			assert.Equal(t, kmsCM.Data[provider.secretNameKey], cephCluster.Spec.Security.KeyManagementService.TokenSecretName, "Failed: %q. Expected the token-names tobe same", kmsArgs.testLabel)
+		}
 	}
 }
 
+func TestCheckKMSConnection(t *testing.T) {
+	reconciler := createFakeInitializationStorageClusterReconciler(t, &nbv1.NooBaa{})
+	sc := createDefaultStorageCluster()
+	sc.Spec.Encryption.KeyManagementService.Enable = true
+
+	// an IBM Key Protect config without the credentials secret name is invalid
+	kmsCM := createDummyKMSConfigMap(IbmKeyProtectKMSProvider, "", "")
+	delete(kmsCM.Data, "IBM_KP_SECRET_NAME")
+	err := reconciler.checkKMSConnection(sc, kmsCM)
+	assert.ErrorContains(t, err, "IBM_KP_SECRET_NAME")
+	condition := conditionsv1.FindStatusCondition(sc.Status.Conditions, api.ConditionKMSConnected)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, ocsutil.KMSInvalidConfigReason, condition.Reason)
+
+	// a valid config
+	kmsCM = createDummyKMSConfigMap(IbmKeyProtectKMSProvider, "", "")
+	assert.NilError(t, reconciler.checkKMSConnection(sc, kmsCM))
+	condition = conditionsv1.FindStatusCondition(sc.Status.Conditions, api.ConditionKMSConnected)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, ocsutil.KMSConnectedReason, condition.Reason)
+
+	// an unreachable provider is reported
+	kmsCM = createDummyKMSConfigMap(VaultKMSProvider, "https://unreachable.url.location:8200", "")
+	assert.Assert(t, reconciler.checkKMSConnection(sc, kmsCM) != nil)
+	condition = conditionsv1.FindStatusCondition(sc.Status.Conditions, api.ConditionKMSConnected)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, ocsutil.KMSUnreachableReason, condition.Reason)
+
+	// the KMSConnected condition is removed once encryption is disabled
+	var obj ocsCephCluster
+	reconciler.initializeImagesStatus(sc)
+	_, err = obj.ensureCreated(&reconciler, sc)
+	assert.NilError(t, err)
+	assert.Assert(t, conditionsv1.FindStatusCondition(sc.Status.Conditions, api.ConditionKMSConnected) == nil)
+}
+
 func TestStorageClassDeviceSetCreationForArbiter(t *testing.T) {
 
 	sc1 := &api.StorageCluster{}
@@ -654,6 +695,171 @@ func TestStorageClassDeviceSetCreationForArbiter(t *testing.T) {
 
 }
 
+func TestStorageClassDeviceSetCreationWithDeviceClassProfiles(t *testing.T) {
+	profileResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("4"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("4"),
+		},
+	}
+	memoryTarget := resource.MustParse("8Gi")
+	profilePlacement := cephv1.Placement{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{
+								Key:      "nvme-node",
+								Operator: corev1.NodeSelectorOpExists,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	sc := &api.StorageCluster{}
+	sc.Spec.DeviceClassProfiles = []api.DeviceClassProfile{
+		{
+			DeviceClass:     "NVMe",
+			Resources:       &profileResources,
+			OSDMemoryTarget: &memoryTarget,
+			Placement:       &profilePlacement,
+			Config:          &api.StorageDeviceSetConfig{TuneFastDeviceClass: true},
+		},
+		{
+			DeviceClass: "hdd",
+			Config:      &api.StorageDeviceSetConfig{TuneSlowDeviceClass: true},
+		},
+	}
+
+	nvmeSet := getMockDeviceSets("nvme", 1, 1, true)[0]
+	nvmeSet.DeviceType = "nvme"
+	hddSet := getMockDeviceSets("hdd", 1, 1, true)[0]
+	hddSet.DeviceType = "ssd"
+	hddSet.DeviceClass = "hdd"
+	// explicit StorageDeviceSet fields take precedence over the profile
+	customSet := getMockDeviceSets("custom", 1, 1, true)[0]
+	customSet.DeviceType = "nvme"
+	customSet.Resources = defaults.DaemonResources["mds"]
+	otherSet := getMockDeviceSets("other", 1, 1, true)[0]
+	otherSet.DeviceType = "ssd"
+	sc.Spec.StorageDeviceSets = []api.StorageDeviceSet{nvmeSet, hddSet, customSet, otherSet}
+	sc.Status.NodeTopologies = &api.NodeTopologyMap{
+		Labels: map[string]api.TopologyLabelValues{
+			zoneTopologyLabel: []string{
+				"zone1",
+				"zone2",
+				"zone3",
+			},
+		},
+	}
+
+	serverVersion := &version.Info{
+		Major: defaults.KubeMajorTopologySpreadConstraints,
+		Minor: defaults.KubeMinorTopologySpreadConstraints,
+	}
+	setFailureDomain(sc)
+	actual := newStorageClassDeviceSets(sc, serverVersion)
+	assert.Equal(t, 4, len(actual))
+
+	expectedMemory := resource.MustParse("10Gi")
+	nvme := actual[0]
+	assert.Equal(t, "nvme-0", nvme.Name)
+	assert.Assert(t, nvme.Resources.Requests.Cpu().Equal(resource.MustParse("4")))
+	assert.Assert(t, nvme.Resources.Requests.Memory().Equal(expectedMemory))
+	assert.Assert(t, nvme.Resources.Limits.Memory().Equal(expectedMemory))
+	assert.DeepEqual(t, profilePlacement, nvme.Placement)
+	assert.DeepEqual(t, profilePlacement, *nvme.PreparePlacement)
+	assert.Equal(t, true, nvme.TuneFastDeviceClass)
+	assert.Equal(t, false, nvme.TuneSlowDeviceClass)
+	// the profile itself must be left untouched
+	_, found := profileResources.Requests[corev1.ResourceMemory]
+	assert.Equal(t, false, found)
+
+	hdd := actual[1]
+	assert.DeepEqual(t, defaults.DaemonResources["osd"], hdd.Resources)
+	assert.Equal(t, true, hdd.TuneSlowDeviceClass)
+
+	custom := actual[2]
+	assert.DeepEqual(t, defaults.DaemonResources["mds"], custom.Resources)
+	assert.DeepEqual(t, profilePlacement, custom.Placement)
+
+	other := actual[3]
+	assert.DeepEqual(t, defaults.DaemonResources["osd"], other.Resources)
+	assert.DeepEqual(t, getPlacement(sc, "osd-tsc"), other.Placement)
+	assert.Equal(t, false, other.TuneFastDeviceClass)
+	assert.Equal(t, false, other.TuneSlowDeviceClass)
+}
+
+func TestValidateDeviceClassProfiles(t *testing.T) {
+	memoryTarget := resource.MustParse("4Gi")
+	zero := resource.MustParse("0")
+
+	cases := []struct {
+		label     string
+		profiles  []api.DeviceClassProfile
+		expectErr bool
+	}{
+		{
+			label: "valid profiles",
+			profiles: []api.DeviceClassProfile{
+				{DeviceClass: "ssd", OSDMemoryTarget: &memoryTarget},
+				{DeviceClass: "hdd", Config: &api.StorageDeviceSetConfig{TuneSlowDeviceClass: true}},
+			},
+		},
+		{
+			label:     "missing device class",
+			profiles:  []api.DeviceClassProfile{{OSDMemoryTarget: &memoryTarget}},
+			expectErr: true,
+		},
+		{
+			label:     "duplicate device class",
+			profiles:  []api.DeviceClassProfile{{DeviceClass: "ssd"}, {DeviceClass: "SSD"}},
+			expectErr: true,
+		},
+		{
+			label:     "zero memory target",
+			profiles:  []api.DeviceClassProfile{{DeviceClass: "ssd", OSDMemoryTarget: &zero}},
+			expectErr: true,
+		},
+		{
+			label: "memory target with memory resources",
+			profiles: []api.DeviceClassProfile{
+				{
+					DeviceClass:     "ssd",
+					OSDMemoryTarget: &memoryTarget,
+					Resources:       &corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			label: "slow and fast tuning",
+			profiles: []api.DeviceClassProfile{
+				{DeviceClass: "ssd", Config: &api.StorageDeviceSetConfig{TuneSlowDeviceClass: true, TuneFastDeviceClass: true}},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Logf("Case: %s\n", c.label)
+		sc := &api.StorageCluster{}
+		sc.Spec.DeviceClassProfiles = c.profiles
+		err := validateDeviceClassProfiles(sc, logf.Log.WithName("storagecluster_test"))
+		if c.expectErr {
+			assert.Assert(t, err != nil)
+		} else {
+			assert.NilError(t, err)
+		}
+	}
+}
+
 func TestNewCephDaemonResources(t *testing.T) {
 
 	cases := []struct {
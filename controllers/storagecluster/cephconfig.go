@@ -23,6 +23,10 @@ const (
 	rookConfigMapName = "rook-config-override"
 	globalSectionKey  = "global"
 	publicNetworkKey  = "public_network"
+
+	// osdMemoryTargetCgroupLimitRatio must match the
+	// osd_memory_target_cgroup_limit_ratio of defaultRookConfigData
+	osdMemoryTargetCgroupLimitRatio = 0.8
 )
 
 var (
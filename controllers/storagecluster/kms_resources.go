@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"time"
 
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
 	ocsv1 "github.com/red-hat-storage/ocs-operator/api/v1"
+	statusutil "github.com/red-hat-storage/ocs-operator/controllers/util"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
@@ -33,10 +35,26 @@ const (
 	IbmKeyProtectKMSProvider = "ibmkeyprotect"
 )
 
+// kmsProvider holds the KMS ConfigMap keys a KMS provider is configured with
+type kmsProvider struct {
+	// addressKey is the key of the provider address, used to check it is reachable
+	addressKey string
+	// secretNameKey is the key of the name of the secret holding the provider credentials
+	secretNameKey string
+	// requiredKeys are the keys the provider can't be configured without
+	requiredKeys []string
+}
+
 var (
-	// currently supported KMS providers mapped to their address key
-	kmsProviderAddressKeyMap = map[string]string{
-		VaultKMSProvider: "VAULT_ADDR",
+	// currently supported KMS providers mapped to their connection details
+	kmsProviders = map[string]kmsProvider{
+		VaultKMSProvider: {
+			addressKey: "VAULT_ADDR",
+		},
+		IbmKeyProtectKMSProvider: {
+			secretNameKey: "IBM_KP_SECRET_NAME",
+			requiredKeys:  []string{"IBM_KP_SERVICE_INSTANCE_ID", "IBM_KP_SECRET_NAME"},
+		},
 	}
 )
 
@@ -155,6 +173,30 @@ func getKMSSecretToken(instance *ocsv1.StorageCluster, client client.Client) (*c
 	return kmsSecretToken, err
 }
 
+// validateKMSConfigMap function checks the KMS ConfigMap has all the details
+// required by its KMS provider. Unknown providers are not validated, that is
+// left to rook.
+func validateKMSConfigMap(kmsConfigMap *corev1.ConfigMap) error {
+	if kmsConfigMap == nil {
+		return fmt.Errorf("please provide a valid config map")
+	}
+	kmsProviderName := kmsConfigMap.Data[KMSProviderKey]
+	provider, ok := kmsProviders[kmsProviderName]
+	if !ok {
+		return nil
+	}
+	var missingKeys []string
+	for _, key := range provider.requiredKeys {
+		if kmsConfigMap.Data[key] == "" {
+			missingKeys = append(missingKeys, key)
+		}
+	}
+	if len(missingKeys) > 0 {
+		return fmt.Errorf("KMS ConfigMap is missing %v required by the %q KMS provider", missingKeys, kmsProviderName)
+	}
+	return nil
+}
+
 // reachKMSProvider function checks whether the provided address is reachable or not.
 // This function won't validate any other cases and only returns an error if the provided
 // KMS provider address is not reachable. All other validations will be done on rook side.
@@ -166,15 +208,49 @@ func reachKMSProvider(kmsConfigMap *corev1.ConfigMap) error {
 	if !ok {
 		return nil
 	}
-	var kmsProviderAddressKey string
-	if kmsProviderAddressKey, ok = kmsProviderAddressKeyMap[kmsProviderName]; !ok {
+	provider, ok := kmsProviders[kmsProviderName]
+	if !ok || provider.addressKey == "" {
 		// cannot find an address key specific for this KMS provider
 		// nothing to validate
 		return nil
 	}
-	kmsAddress, ok := kmsConfigMap.Data[kmsProviderAddressKey]
+	kmsAddress, ok := kmsConfigMap.Data[provider.addressKey]
 	if !ok {
 		return nil
 	}
 	return checkEndpointReachable(kmsAddress, 5*time.Second)
 }
+
+// checkKMSConnection function validates the KMS ConfigMap and checks the KMS provider
+// is reachable. The outcome is reported by the KMSConnected condition of the StorageCluster.
+func (r *StorageClusterReconciler) checkKMSConnection(sc *ocsv1.StorageCluster, kmsConfigMap *corev1.ConfigMap) error {
+	if err := validateKMSConfigMap(kmsConfigMap); err != nil {
+		r.Log.Error(err, "Invalid KMS ConfigMap.", "KMSConfigMap", klog.KRef(kmsConfigMap.Namespace, kmsConfigMap.Name))
+		setKMSConnectedCondition(sc, corev1.ConditionFalse, statusutil.KMSInvalidConfigReason, err.Error())
+		return err
+	}
+	if err := reachKMSProvider(kmsConfigMap); err != nil {
+		r.Log.Error(err, "Address provided in KMS ConfigMap is not reachable.", "KMSConfigMap", klog.KRef(kmsConfigMap.Namespace, kmsConfigMap.Name))
+		setKMSConnectedCondition(sc, corev1.ConditionFalse, statusutil.KMSUnreachableReason, err.Error())
+		return err
+	}
+
+	setKMSConnectedCondition(sc, corev1.ConditionTrue, statusutil.KMSConnectedReason,
+		fmt.Sprintf("KMS provider %q is reachable", kmsConfigMap.Data[KMSProviderKey]))
+	return nil
+}
+
+func setKMSConnectedCondition(sc *ocsv1.StorageCluster, status corev1.ConditionStatus, reason, message string) {
+	conditionsv1.SetStatusCondition(&sc.Status.Conditions, conditionsv1.Condition{
+		Type:    ocsv1.ConditionKMSConnected,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// clearKMSConnectedStatus function removes the KMSConnected condition of the
+// StorageCluster, once encryption with a KMS is disabled or its ConfigMap is gone
+func clearKMSConnectedStatus(sc *ocsv1.StorageCluster) {
+	conditionsv1.RemoveStatusCondition(&sc.Status.Conditions, ocsv1.ConditionKMSConnected)
+}
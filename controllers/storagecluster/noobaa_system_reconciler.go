@@ -203,9 +203,9 @@ func (r *StorageClusterReconciler) setNooBaaDesiredState(nb *nbv1.NooBaa, sc *oc
 					// Secret is created by UI in "openshift-storage" namespace
 					nb.Spec.Security.KeyManagementService.TokenSecretName = KMSTokenSecretName
 				}
-			} else if kmsConfig.Data["KMS_PROVIDER"] == IbmKeyProtectKMSProvider {
+			} else if provider, ok := kmsProviders[kmsConfig.Data["KMS_PROVIDER"]]; ok && provider.secretNameKey != "" {
 				// Secret is created by UI in "openshift-storage" namespace
-				nb.Spec.Security.KeyManagementService.TokenSecretName = kmsConfig.Data["IBM_KP_SECRET_NAME"]
+				nb.Spec.Security.KeyManagementService.TokenSecretName = kmsConfig.Data[provider.secretNameKey]
 			}
 			nb.Spec.Security.KeyManagementService.ConnectionDetails = kmsConfig.Data
 		}
@@ -231,6 +231,17 @@ func (r *StorageClusterReconciler) validateStorageClusterSpec(instance *ocsv1.St
 		return err
 	}
 
+	if err := validateDeviceClassProfiles(instance, r.Log); err != nil {
+		r.Log.Error(err, "Failed to validate DeviceClassProfiles.", "StorageCluster", klog.KRef(instance.Namespace, instance.Name))
+		r.recorder.ReportIfNotPresent(instance, corev1.EventTypeWarning, statusutil.EventReasonValidationFailed, err.Error())
+		instance.Status.Phase = statusutil.PhaseError
+		if updateErr := r.Client.Status().Update(context.TODO(), instance); updateErr != nil {
+			r.Log.Error(updateErr, "Could not update StorageCluster.", "StorageCluster", klog.KRef(instance.Namespace, instance.Name))
+			return updateErr
+		}
+		return err
+	}
+
 	if err := validateOverprovisionControlSpec(instance, r.Log); err != nil {
 		r.Log.Error(err, "Failed to validate OverprovisionControlSpec.", "StorageCluster", klog.KRef(instance.Namespace, instance.Name))
 		r.recorder.ReportIfNotPresent(instance, corev1.EventTypeWarning, statusutil.EventReasonValidationFailed, err.Error())
@@ -653,6 +664,37 @@ func validateArbiterSpec(sc *ocsv1.StorageCluster, reqLogger logr.Logger) error
 	return nil
 }
 
+func validateDeviceClassProfiles(sc *ocsv1.StorageCluster, reqLogger logr.Logger) error {
+	deviceClasses := map[string]bool{}
+	for _, profile := range sc.Spec.DeviceClassProfiles {
+		if profile.DeviceClass == "" {
+			return fmt.Errorf("missing deviceClass in deviceClassProfiles")
+		}
+		deviceClass := strings.ToLower(profile.DeviceClass)
+		if deviceClasses[deviceClass] {
+			return fmt.Errorf("duplicate deviceClassProfile for device class %s", profile.DeviceClass)
+		}
+		deviceClasses[deviceClass] = true
+
+		if profile.OSDMemoryTarget != nil {
+			if profile.OSDMemoryTarget.Sign() <= 0 {
+				return fmt.Errorf("osdMemoryTarget can not be a zero or negative value for device class %s", profile.DeviceClass)
+			}
+			if profile.Resources != nil {
+				_, hasRequest := profile.Resources.Requests[corev1.ResourceMemory]
+				_, hasLimit := profile.Resources.Limits[corev1.ResourceMemory]
+				if hasRequest || hasLimit {
+					return fmt.Errorf("osdMemoryTarget and memory resources can not be set together for device class %s", profile.DeviceClass)
+				}
+			}
+		}
+		if profile.Config != nil && profile.Config.TuneSlowDeviceClass && profile.Config.TuneFastDeviceClass {
+			return fmt.Errorf("tuneSlowDeviceClass and tuneFastDeviceClass can not be set together for device class %s", profile.DeviceClass)
+		}
+	}
+	return nil
+}
+
 func validateOverprovisionControlSpec(sc *ocsv1.StorageCluster, reqLogger logr.Logger) error {
 	for _, opc := range sc.Spec.OverprovisionControl {
 		val, ok := opc.Capacity.AsInt64()
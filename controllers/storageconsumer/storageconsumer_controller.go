@@ -22,6 +22,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/go-logr/logr"
 	ocsv1 "github.com/red-hat-storage/ocs-operator/api/v1"
@@ -39,10 +40,11 @@ import (
 )
 
 const (
-	storageConsumerFinalizer      = "storagesconsumer.ocs.openshift.io"
-	StorageConsumerAnnotation     = "ocs.openshift.io.storageconsumer"
-	StorageClaimAnnotation        = "ocs.openshift.io.storageclaim"
-	StorageCephUserTypeAnnotation = "ocs.openshift.io.cephusertype"
+	storageConsumerFinalizer           = "storagesconsumer.ocs.openshift.io"
+	StorageConsumerAnnotation          = "ocs.openshift.io.storageconsumer"
+	StorageClaimAnnotation             = "ocs.openshift.io.storageclaim"
+	StorageCephUserTypeAnnotation      = "ocs.openshift.io.cephusertype"
+	CephClientKeysGenerationAnnotation = "ocs.openshift.io.cephclientkeysgeneration"
 )
 
 // StorageConsumerReconciler reconciles a StorageConsumer object
@@ -174,6 +176,29 @@ func (r *StorageConsumerReconciler) reconcilePhases() (reconcile.Result, error)
 			}
 		}
 
+		if r.storageConsumer.Spec.Revoked {
+			// Deleting the CephClients revokes their keys, the storage of the consumer is kept
+			r.storageConsumer.Status.State = v1alpha1.StorageConsumerStateRevoked
+			if err := r.deleteCephClients(); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, nil
+		}
+
+		if r.storageConsumer.Status.CephClientKeysGeneration != r.storageConsumer.Spec.CephClientKeysGeneration {
+			// Deleting the CephClients revokes their keys, new keys are generated
+			// when the CephClients are created again once rook has removed them
+			r.Log.Info("Rotating the keys of the CephClients of the StorageConsumer.", "StorageConsumer", klog.KRef(r.storageConsumer.Namespace, r.storageConsumer.Name))
+			pending, err := r.deleteOutdatedCephClients()
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if pending {
+				// The consumer stays in Configuring state until the CephClients are created again
+				return reconcile.Result{Requeue: true}, nil
+			}
+		}
+
 		if err := r.reconcileCephClientRBDProvisioner(); err != nil {
 			return reconcile.Result{}, err
 		}
@@ -211,6 +236,8 @@ func (r *StorageConsumerReconciler) reconcilePhases() (reconcile.Result, error)
 		}
 
 		if cephResourcesReady {
+			// All the CephClients have been created with the keys of the current generation
+			r.storageConsumer.Status.CephClientKeysGeneration = r.storageConsumer.Spec.CephClientKeysGeneration
 			r.storageConsumer.Status.State = v1alpha1.StorageConsumerStateReady
 		}
 
@@ -394,6 +421,7 @@ func (r *StorageConsumerReconciler) reconcileCephClientRBDProvisioner() error {
 		}
 
 		addStorageRelatedAnnotations(r.cephClientRBDProvisioner, r.storageConsumer.Name, "rbd", "provisioner")
+		r.setCephClientKeysGeneration(r.cephClientRBDProvisioner)
 		r.cephClientRBDProvisioner.Spec = desired.Spec
 		return nil
 	})
@@ -435,6 +463,7 @@ func (r *StorageConsumerReconciler) reconcileCephClientRBDNode() error {
 		}
 
 		addStorageRelatedAnnotations(r.cephClientRBDNode, r.storageConsumer.Name, "rbd", "node")
+		r.setCephClientKeysGeneration(r.cephClientRBDNode)
 		r.cephClientRBDNode.Spec = desired.Spec
 		return nil
 	})
@@ -467,6 +496,7 @@ func (r *StorageConsumerReconciler) reconcileCephClientCephFSProvisioner() error
 		}
 
 		addStorageRelatedAnnotations(r.cephClientCephFSProvisioner, r.storageConsumer.Name, "cephfs", "provisioner")
+		r.setCephClientKeysGeneration(r.cephClientCephFSProvisioner)
 		r.cephClientCephFSProvisioner.Spec = rookCephv1.ClientSpec{
 			Caps: map[string]string{
 				"mon": "allow r",
@@ -506,6 +536,7 @@ func (r *StorageConsumerReconciler) reconcileCephClientCephFSNode() error {
 		}
 
 		addStorageRelatedAnnotations(r.cephClientCephFSNode, r.storageConsumer.Name, "cephfs", "node")
+		r.setCephClientKeysGeneration(r.cephClientCephFSNode)
 		r.cephClientCephFSNode.Spec = rookCephv1.ClientSpec{
 			Caps: map[string]string{
 				"mon": "allow r",
@@ -554,6 +585,7 @@ func (r *StorageConsumerReconciler) reconcileCephClientHealthChecker() error {
 		}
 
 		addStorageRelatedAnnotations(r.cephClientHealthChecker, r.storageConsumer.Name, "global", "healthchecker")
+		r.setCephClientKeysGeneration(r.cephClientHealthChecker)
 		r.cephClientHealthChecker.Spec = desired.Spec
 		return nil
 	})
@@ -578,6 +610,53 @@ func (r *StorageConsumerReconciler) reconcileCephClientHealthChecker() error {
 	return nil
 }
 
+func (r *StorageConsumerReconciler) deleteCephClients() error {
+	for _, cephResource := range r.storageConsumer.Status.CephResources {
+		if cephResource.Kind != "CephClient" {
+			continue
+		}
+		cephClient := &rookCephv1.CephClient{}
+		cephClient.Name = cephResource.Name
+		cephClient.Namespace = r.namespace
+		if err := r.delete(cephClient); err != nil {
+			return fmt.Errorf("unable to delete CephClient : %v", err)
+		}
+		cephResource.Phase = ""
+	}
+	return nil
+}
+
+// deleteOutdatedCephClients deletes the CephClients holding keys of a previous CephClientKeysGeneration
+// and returns true while any of them has not been removed by rook yet
+func (r *StorageConsumerReconciler) deleteOutdatedCephClients() (bool, error) {
+	pending := false
+	for _, cephResource := range r.storageConsumer.Status.CephResources {
+		if cephResource.Kind != "CephClient" {
+			continue
+		}
+		cephClient := &rookCephv1.CephClient{}
+		cephClient.Name = cephResource.Name
+		cephClient.Namespace = r.namespace
+		if err := r.get(cephClient); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return false, fmt.Errorf("unable to get CephClient : %v", err)
+		}
+		if cephClient.GetDeletionTimestamp().IsZero() && getCephClientKeysGeneration(cephClient) == r.storageConsumer.Spec.CephClientKeysGeneration {
+			continue
+		}
+		if cephClient.GetDeletionTimestamp().IsZero() {
+			if err := r.delete(cephClient); err != nil {
+				return false, fmt.Errorf("unable to delete CephClient : %v", err)
+			}
+		}
+		cephResource.Phase = ""
+		pending = true
+	}
+	return pending, nil
+}
+
 func (r *StorageConsumerReconciler) verifyCephResourcesDoNotExist() bool {
 	for _, cephResource := range r.storageConsumer.Status.CephResources {
 		switch cephResource.Kind {
@@ -692,6 +771,27 @@ func generateHashForCephClient(storageConsumerName, claimID, cephUserType string
 	return hex.EncodeToString(name[:16])
 }
 
+// getCephClientKeysGeneration returns the CephClientKeysGeneration the CephClient was created for.
+// CephClients created before key rotation was available have none, which is generation 0.
+func getCephClientKeysGeneration(cephClient *rookCephv1.CephClient) int64 {
+	generation, err := strconv.ParseInt(cephClient.GetAnnotations()[CephClientKeysGenerationAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return generation
+}
+
+// setCephClientKeysGeneration records the current CephClientKeysGeneration of the consumer in the CephClient
+func (r *StorageConsumerReconciler) setCephClientKeysGeneration(cephClient *rookCephv1.CephClient) {
+	annotations := cephClient.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+		cephClient.SetAnnotations(annotations)
+	}
+
+	annotations[CephClientKeysGenerationAnnotation] = strconv.FormatInt(r.storageConsumer.Spec.CephClientKeysGeneration, 10)
+}
+
 func addStorageRelatedAnnotations(obj client.Object, storageConsumerName, storageClaim, cephUserType string) {
 	annotations := obj.GetAnnotations()
 	if annotations == nil {
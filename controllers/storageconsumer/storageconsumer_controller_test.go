@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	ocsv1 "github.com/red-hat-storage/ocs-operator/api/v1"
+	ocsv1alpha1 "github.com/red-hat-storage/ocs-operator/api/v1alpha1"
+	rookCephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	testNamespace           = "openshift-storage"
+	testConsumerName        = "consumer1"
+	rookCephClientFinalizer = "cephclient.ceph.rook.io"
+)
+
+var request = reconcile.Request{
+	NamespacedName: types.NamespacedName{
+		Name:      testConsumerName,
+		Namespace: testNamespace,
+	},
+}
+
+func createFakeScheme(t *testing.T) *runtime.Scheme {
+	scheme, err := ocsv1.SchemeBuilder.Build()
+	if err != nil {
+		assert.Fail(t, "unable to build scheme")
+	}
+	err = ocsv1alpha1.AddToScheme(scheme)
+	if err != nil {
+		assert.Fail(t, "failed to add ocsv1alpha1 scheme")
+	}
+	err = rookCephv1.AddToScheme(scheme)
+	if err != nil {
+		assert.Fail(t, "failed to add rookCephv1 scheme")
+	}
+	return scheme
+}
+
+func createFakeStorageConsumerReconciler(t *testing.T, obj ...runtime.Object) *StorageConsumerReconciler {
+	scheme := createFakeScheme(t)
+	storageCluster := &ocsv1.StorageCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "ocs-storagecluster", Namespace: testNamespace},
+		Status:     ocsv1.StorageClusterStatus{FailureDomain: "zone"},
+	}
+	cephFilesystem := &rookCephv1.CephFilesystem{
+		ObjectMeta: metav1.ObjectMeta{Name: "ocs-storagecluster-cephfilesystem", Namespace: testNamespace},
+	}
+	obj = append(obj, storageCluster, cephFilesystem)
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(obj...).Build()
+
+	return &StorageConsumerReconciler{
+		Client: client,
+		Scheme: scheme,
+		Log:    logf.Log.WithName("controller_storageconsumer_test"),
+	}
+}
+
+func getStorageConsumer(t *testing.T, r *StorageConsumerReconciler) *ocsv1alpha1.StorageConsumer {
+	consumer := &ocsv1alpha1.StorageConsumer{}
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, consumer))
+	return consumer
+}
+
+func getCephClients(t *testing.T, r *StorageConsumerReconciler) []rookCephv1.CephClient {
+	cephClients := &rookCephv1.CephClientList{}
+	assert.NoError(t, r.Client.List(context.TODO(), cephClients, client.InNamespace(testNamespace)))
+	return cephClients.Items
+}
+
+// setCephResourcesReady sets the phase of the ceph resources to Ready, as rook does
+func setCephResourcesReady(t *testing.T, r *StorageConsumerReconciler) {
+	ctx := context.TODO()
+	cephClients := getCephClients(t, r)
+	for i := range cephClients {
+		cephClient := &cephClients[i]
+		cephClient.Status = &rookCephv1.CephClientStatus{Phase: rookCephv1.ConditionReady}
+		assert.NoError(t, r.Client.Update(ctx, cephClient))
+	}
+
+	cephBlockPool := &rookCephv1.CephBlockPool{}
+	assert.NoError(t, r.Client.Get(ctx, types.NamespacedName{Name: "cephblockpool-" + testConsumerName, Namespace: testNamespace}, cephBlockPool))
+	cephBlockPool.Status = &rookCephv1.CephBlockPoolStatus{Phase: rookCephv1.ConditionReady}
+	assert.NoError(t, r.Client.Update(ctx, cephBlockPool))
+
+	subVolumeGroup := &rookCephv1.CephFilesystemSubVolumeGroup{}
+	assert.NoError(t, r.Client.Get(ctx, types.NamespacedName{Name: "cephfilesystemsubvolumegroup-" + testConsumerName, Namespace: testNamespace}, subVolumeGroup))
+	subVolumeGroup.Status = &rookCephv1.CephFilesystemSubVolumeGroupStatus{Phase: rookCephv1.ConditionReady}
+	assert.NoError(t, r.Client.Update(ctx, subVolumeGroup))
+}
+
+// setCephClientsFinalizer adds the finalizer rook sets on the CephClients, which keeps them
+// around until their keys are removed
+func setCephClientsFinalizer(t *testing.T, r *StorageConsumerReconciler, enabled bool) {
+	for _, cephClient := range getCephClients(t, r) {
+		cephClient := cephClient
+		if enabled {
+			cephClient.Finalizers = append(cephClient.Finalizers, rookCephClientFinalizer)
+		} else {
+			cephClient.Finalizers = remove(cephClient.Finalizers, rookCephClientFinalizer)
+		}
+		assert.NoError(t, r.Client.Update(context.TODO(), &cephClient))
+	}
+}
+
+// reconcileToReady reconciles a new StorageConsumer until it is Ready
+func reconcileToReady(t *testing.T, r *StorageConsumerReconciler) {
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, ocsv1alpha1.StorageConsumerStateConfiguring, getStorageConsumer(t, r).Status.State)
+	assert.Len(t, getCephClients(t, r), 5)
+
+	setCephResourcesReady(t, r)
+	_, err = r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, ocsv1alpha1.StorageConsumerStateReady, getStorageConsumer(t, r).Status.State)
+}
+
+func TestRotateCephClientKeys(t *testing.T) {
+	ctx := context.TODO()
+	consumer := &ocsv1alpha1.StorageConsumer{
+		ObjectMeta: metav1.ObjectMeta{Name: testConsumerName, Namespace: testNamespace},
+		Spec:       ocsv1alpha1.StorageConsumerSpec{Capacity: resource.MustParse("1T")},
+	}
+	r := createFakeStorageConsumerReconciler(t, consumer)
+	reconcileToReady(t, r)
+	for _, cephClient := range getCephClients(t, r) {
+		assert.Equal(t, int64(0), getCephClientKeysGeneration(&cephClient))
+	}
+
+	// requesting new keys deletes the CephClients and keeps the consumer out of Ready
+	setCephClientsFinalizer(t, r, true)
+	consumer = getStorageConsumer(t, r)
+	consumer.Spec.CephClientKeysGeneration = 1
+	assert.NoError(t, r.Client.Update(ctx, consumer))
+
+	for i := 0; i < 2; i++ {
+		result, err := r.Reconcile(ctx, request)
+		assert.NoError(t, err)
+		assert.True(t, result.Requeue)
+		consumer = getStorageConsumer(t, r)
+		assert.Equal(t, ocsv1alpha1.StorageConsumerStateConfiguring, consumer.Status.State)
+		assert.Equal(t, int64(0), consumer.Status.CephClientKeysGeneration)
+		for _, cephResource := range consumer.Status.CephResources {
+			if cephResource.Kind == "CephClient" {
+				assert.Equal(t, "", cephResource.Phase)
+			}
+		}
+		for _, cephClient := range getCephClients(t, r) {
+			assert.False(t, cephClient.GetDeletionTimestamp().IsZero())
+		}
+	}
+
+	// the CephClients are created again once rook has removed them
+	setCephClientsFinalizer(t, r, false)
+	assert.Len(t, getCephClients(t, r), 0)
+	_, err := r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	consumer = getStorageConsumer(t, r)
+	assert.Equal(t, ocsv1alpha1.StorageConsumerStateConfiguring, consumer.Status.State)
+	assert.Equal(t, int64(0), consumer.Status.CephClientKeysGeneration)
+	cephClients := getCephClients(t, r)
+	assert.Len(t, cephClients, 5)
+	for _, cephClient := range cephClients {
+		assert.Equal(t, int64(1), getCephClientKeysGeneration(&cephClient))
+	}
+
+	// the rotation completes when the new CephClients are Ready
+	setCephResourcesReady(t, r)
+	_, err = r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	consumer = getStorageConsumer(t, r)
+	assert.Equal(t, ocsv1alpha1.StorageConsumerStateReady, consumer.Status.State)
+	assert.Equal(t, int64(1), consumer.Status.CephClientKeysGeneration)
+}
+
+func TestRevokeStorageConsumer(t *testing.T) {
+	ctx := context.TODO()
+	consumer := &ocsv1alpha1.StorageConsumer{
+		ObjectMeta: metav1.ObjectMeta{Name: testConsumerName, Namespace: testNamespace},
+		Spec:       ocsv1alpha1.StorageConsumerSpec{Capacity: resource.MustParse("1T")},
+	}
+	r := createFakeStorageConsumerReconciler(t, consumer)
+	reconcileToReady(t, r)
+
+	consumer = getStorageConsumer(t, r)
+	consumer.Spec.Revoked = true
+	assert.NoError(t, r.Client.Update(ctx, consumer))
+
+	_, err := r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	consumer = getStorageConsumer(t, r)
+	assert.Equal(t, ocsv1alpha1.StorageConsumerStateRevoked, consumer.Status.State)
+	assert.Len(t, getCephClients(t, r), 0)
+	for _, cephResource := range consumer.Status.CephResources {
+		if cephResource.Kind == "CephClient" {
+			assert.Equal(t, "", cephResource.Phase)
+		}
+	}
+
+	// the storage of the consumer is kept
+	cephBlockPool := &rookCephv1.CephBlockPool{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: "cephblockpool-" + testConsumerName, Namespace: testNamespace}, cephBlockPool)
+	assert.False(t, errors.IsNotFound(err))
+	subVolumeGroup := &rookCephv1.CephFilesystemSubVolumeGroup{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: "cephfilesystemsubvolumegroup-" + testConsumerName, Namespace: testNamespace}, subVolumeGroup)
+	assert.False(t, errors.IsNotFound(err))
+
+	// a revoked consumer stays revoked
+	_, err = r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, ocsv1alpha1.StorageConsumerStateRevoked, getStorageConsumer(t, r).Status.State)
+	assert.Len(t, getCephClients(t, r), 0)
+}
@@ -38,6 +38,12 @@ const (
 	ExternalClusterUnknownReason = "ExternalClusterStateUnknownCondition"
 	// ExternalClusterErrorReason indicates an error state
 	ExternalClusterErrorReason = "ExternalClusterStateError"
+	// KMSConnectedReason indicates the KMS provider is valid and reachable
+	KMSConnectedReason = "KMSConnected"
+	// KMSInvalidConfigReason indicates the KMS ConfigMap lacks details required by the KMS provider
+	KMSInvalidConfigReason = "KMSInvalidConfig"
+	// KMSUnreachableReason indicates the KMS provider address is not reachable
+	KMSUnreachableReason = "KMSUnreachable"
 )
 
 // SetProgressingCondition sets the ProgressingCondition to True and other conditions to
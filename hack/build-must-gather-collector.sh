@@ -0,0 +1,9 @@
+#!/bin/bash
+
+set -e
+
+source hack/common.sh
+
+mkdir -p ${OUTDIR_BIN}
+
+go build -tags 'netgo osusergo' -ldflags="-s -w" -o ${OUTDIR_BIN}/gather-collector ./must-gather/collector
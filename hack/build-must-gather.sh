@@ -5,4 +5,4 @@ set -e
 source hack/common.sh
 source hack/docker-common.sh
 
-${IMAGE_BUILD_CMD} build -f must-gather/Dockerfile -t "${MUST_GATHER_FULL_IMAGE_NAME}" must-gather/
+${IMAGE_BUILD_CMD} build -f must-gather/Dockerfile -t "${MUST_GATHER_FULL_IMAGE_NAME}" .
@@ -16,14 +16,26 @@ import (
 const (
 	// component within the project/exporter
 	poolMirroringSubsystem = "pool_mirroring"
+	rbdMirrorSubsystem     = "rbd_mirror"
 )
 
+// mirrorHealth maps the health reported by `rbd mirror pool status` to the
+// metric value, using the same scale as the pool mirroring image health
+var mirrorHealth = map[string]float64{
+	"OK":      0,
+	"UNKNOWN": 1,
+	"WARNING": 2,
+	"ERROR":   3,
+}
+
 var _ prometheus.Collector = &CephBlockPoolCollector{}
 
 // CephBlockPoolCollector is a custom collector for CephBlockPool Custom Resource
 type CephBlockPoolCollector struct {
 	MirroringImageHealth *prometheus.Desc
 	MirroringStatus      *prometheus.Desc
+	MirrorDaemonHealth   *prometheus.Desc
+	MirrorImageState     *prometheus.Desc
 	Informer             cache.SharedIndexInformer
 	AllowedNamespaces    []string
 }
@@ -51,6 +63,18 @@ func NewCephBlockPoolCollector(opts *options.Options) *CephBlockPoolCollector {
 			[]string{"name", "namespace"},
 			nil,
 		),
+		MirrorDaemonHealth: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, mirrorDaemonSubsystem, "health"),
+			`RBD Mirror Daemon Health of the pool. 0=OK, 1=UNKNOWN, 2=WARNING & 3=ERROR`,
+			[]string{"name", "namespace"},
+			nil,
+		),
+		MirrorImageState: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, rbdMirrorSubsystem, "image_state"),
+			`Number of mirrored RBD images of the pool in each mirroring state. This is a per pool count, the state and replication lag of each image are not exported`,
+			[]string{"name", "namespace", "state"},
+			nil,
+		),
 		Informer:          sharedIndexInformer,
 		AllowedNamespaces: opts.AllowedNamespaces,
 	}
@@ -66,6 +90,8 @@ func (c *CephBlockPoolCollector) Describe(ch chan<- *prometheus.Desc) {
 	ds := []*prometheus.Desc{
 		c.MirroringImageHealth,
 		c.MirroringStatus,
+		c.MirrorDaemonHealth,
+		c.MirrorImageState,
 	}
 
 	for _, d := range ds {
@@ -81,6 +107,8 @@ func (c *CephBlockPoolCollector) Collect(ch chan<- prometheus.Metric) {
 	if len(cephBlockPools) > 0 {
 		c.collectMirroringImageHealth(cephBlockPools, ch)
 		c.collectMirroringStatus(cephBlockPools, ch)
+		c.collectMirrorDaemonHealth(cephBlockPools, ch)
+		c.collectMirrorImageState(cephBlockPools, ch)
 	}
 }
 
@@ -157,3 +185,56 @@ func (c *CephBlockPoolCollector) collectMirroringStatus(cephBlockPools []*cephv1
 		}
 	}
 }
+
+func (c *CephBlockPoolCollector) collectMirrorDaemonHealth(cephBlockPools []*cephv1.CephBlockPool, ch chan<- prometheus.Metric) {
+	for _, cephBlockPool := range cephBlockPools {
+		summary := mirroringSummary(cephBlockPool)
+		if summary == nil {
+			continue
+		}
+		value, ok := mirrorHealth[summary.DaemonHealth]
+		if !ok {
+			klog.Errorf("Invalid mirror daemon health for pool %s. Must be OK, UNKNOWN, WARNING or ERROR", cephBlockPool.Name)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.MirrorDaemonHealth,
+			prometheus.GaugeValue, value,
+			cephBlockPool.Name,
+			cephBlockPool.Namespace)
+	}
+}
+
+// collectMirrorImageState exports the image state counts of the mirroring summary in the
+// CephBlockPool status. The status has no per image data, so neither the state of each
+// image nor its replication lag are available here.
+func (c *CephBlockPoolCollector) collectMirrorImageState(cephBlockPools []*cephv1.CephBlockPool, ch chan<- prometheus.Metric) {
+	for _, cephBlockPool := range cephBlockPools {
+		summary := mirroringSummary(cephBlockPool)
+		if summary == nil {
+			continue
+		}
+		states := map[string]int{
+			"starting_replay": summary.States.StartingReplay,
+			"replaying":       summary.States.Replaying,
+			"syncing":         summary.States.Syncing,
+			"stopping_replay": summary.States.StopReplaying,
+			"stopped":         summary.States.Stopped,
+			"unknown":         summary.States.Unknown,
+			"error":           summary.States.Error,
+		}
+		for state, count := range states {
+			ch <- prometheus.MustNewConstMetric(c.MirrorImageState,
+				prometheus.GaugeValue, float64(count),
+				cephBlockPool.Name,
+				cephBlockPool.Namespace,
+				state)
+		}
+	}
+}
+
+func mirroringSummary(cephBlockPool *cephv1.CephBlockPool) *cephv1.PoolMirroringStatusSummarySpec {
+	if cephBlockPool.Status == nil || cephBlockPool.Status.MirroringStatus == nil {
+		return nil
+	}
+	return cephBlockPool.Status.MirroringStatus.Summary
+}
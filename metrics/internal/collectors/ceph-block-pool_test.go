@@ -283,3 +283,53 @@ func TestCollectPoolMirroringStatus(t *testing.T) {
 	}
 
 }
+
+func TestCollectMirrorDaemonHealthAndImageState(t *testing.T) {
+	cephBlockPoolCollector := getMockCephBlockPoolCollector(t, mockOpts)
+
+	objWarning := mockCephBlockPool1.DeepCopy()
+	objWarning.Name = objWarning.Name + "warning"
+	objWarning.Status = &cephv1.CephBlockPoolStatus{
+		MirroringStatus: &cephv1.MirroringStatusSpec{PoolMirroringStatus: cephv1.PoolMirroringStatus{Summary: &cephv1.PoolMirroringStatusSummarySpec{
+			DaemonHealth: "WARNING",
+			States:       cephv1.StatesSpec{Replaying: 3, Error: 1},
+		}}},
+	}
+
+	// pools without mirroring status must not produce metrics
+	objNoStatus := mockCephBlockPool2.DeepCopy()
+
+	cephBlockPools := []*cephv1.CephBlockPool{objWarning, objNoStatus}
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		cephBlockPoolCollector.collectMirrorDaemonHealth(cephBlockPools, ch)
+		close(ch)
+	}()
+	count := 0
+	for m := range ch {
+		count++
+		assert.Contains(t, m.Desc().String(), "mirror_daemon_health")
+		labels, value := metricLabels(t, m)
+		assert.Equal(t, objWarning.Name, labels["name"])
+		assert.Equal(t, float64(2), value)
+	}
+	assert.Equal(t, 1, count)
+
+	ch = make(chan prometheus.Metric)
+	go func() {
+		cephBlockPoolCollector.collectMirrorImageState(cephBlockPools, ch)
+		close(ch)
+	}()
+	states := map[string]float64{}
+	for m := range ch {
+		assert.Contains(t, m.Desc().String(), "rbd_mirror_image_state")
+		labels, value := metricLabels(t, m)
+		assert.Equal(t, objWarning.Name, labels["name"])
+		states[labels["state"]] = value
+	}
+	assert.Len(t, states, 7)
+	assert.Equal(t, float64(3), states["replaying"])
+	assert.Equal(t, float64(1), states["error"])
+	assert.Equal(t, float64(0), states["syncing"])
+}
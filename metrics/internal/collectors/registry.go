@@ -18,14 +18,17 @@ func RegisterCustomResourceCollectors(registry *prometheus.Registry, opts *optio
 	cephBlockPoolCollector := NewCephBlockPoolCollector(opts)
 	cephClusterCollector := NewCephClusterCollector(opts)
 	OBMetricsCollector := NewObjectBucketCollector(opts)
+	volumeReplicationCollector := NewVolumeReplicationCollector(opts)
 	cephObjectStoreCollector.Run(opts.StopCh)
 	cephBlockPoolCollector.Run(opts.StopCh)
 	cephClusterCollector.Run(opts.StopCh)
 	OBMetricsCollector.Run(opts.StopCh)
+	volumeReplicationCollector.Run(opts.StopCh)
 	registry.MustRegister(
 		cephObjectStoreCollector,
 		cephBlockPoolCollector,
 		cephClusterCollector,
 		OBMetricsCollector,
+		volumeReplicationCollector,
 	)
 }
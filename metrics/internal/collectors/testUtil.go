@@ -8,6 +8,8 @@ import (
 
 	libbucket "github.com/kube-object-storage/lib-bucket-provisioner/pkg/apis/objectbucket.io/v1alpha1"
 	bktclient "github.com/kube-object-storage/lib-bucket-provisioner/pkg/client/clientset/versioned"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/red-hat-storage/ocs-operator/metrics/internal/options"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -94,3 +96,14 @@ type MockDoType func(req *http.Request) (*http.Response, error)
 
 // Do is the mock client's `Do` func
 func (m *MockClient) Do(req *http.Request) (*http.Response, error) { return m.MockDo(req) }
+
+func metricLabels(t *testing.T, m prometheus.Metric) (map[string]string, float64) {
+	metric := dto.Metric{}
+	err := m.Write(&metric)
+	assert.Nil(t, err)
+	labels := map[string]string{}
+	for _, label := range metric.GetLabel() {
+		labels[*label.Name] = *label.Value
+	}
+	return labels, *metric.Gauge.Value
+}
@@ -0,0 +1,155 @@
+package collectors
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/red-hat-storage/ocs-operator/metrics/internal/options"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+)
+
+const (
+	// component within the project/exporter
+	volumeReplicationSubsystem = "volume_replication"
+)
+
+var volumeReplicationResource = schema.GroupVersionResource{
+	Group:    "replication.storage.openshift.io",
+	Version:  "v1alpha1",
+	Resource: "volumereplications",
+}
+
+var _ prometheus.Collector = &VolumeReplicationCollector{}
+
+// VolumeReplicationCollector is a custom collector for VolumeReplication Custom Resource
+type VolumeReplicationCollector struct {
+	VolumeReplicationState    *prometheus.Desc
+	VolumeReplicationDegraded *prometheus.Desc
+	Informer                  cache.SharedIndexInformer
+	AllowedNamespaces         []string
+}
+
+// NewVolumeReplicationCollector constructs a collector
+func NewVolumeReplicationCollector(opts *options.Options) *VolumeReplicationCollector {
+	dynamicClient, err := dynamic.NewForConfig(opts.Kubeconfig)
+	if err != nil {
+		klog.Error(err)
+	}
+
+	vrClient := dynamicClient.Resource(volumeReplicationResource)
+	vrLW := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return vrClient.Namespace(metav1.NamespaceAll).List(context.TODO(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return vrClient.Namespace(metav1.NamespaceAll).Watch(context.TODO(), options)
+		},
+	}
+	sharedIndexInformer := cache.NewSharedIndexInformer(vrLW, &unstructured.Unstructured{}, 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	return &VolumeReplicationCollector{
+		VolumeReplicationState: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, volumeReplicationSubsystem, "state"),
+			`VolumeReplication State. 1 for the current state (Primary, Secondary, Resync or Unknown)`,
+			[]string{"name", "namespace", "state"},
+			nil,
+		),
+		VolumeReplicationDegraded: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, volumeReplicationSubsystem, "degraded"),
+			`VolumeReplication Degraded condition. 0=False, 1=True`,
+			[]string{"name", "namespace"},
+			nil,
+		),
+		Informer:          sharedIndexInformer,
+		AllowedNamespaces: opts.AllowedNamespaces,
+	}
+}
+
+// Run starts VolumeReplication informer
+func (c *VolumeReplicationCollector) Run(stopCh <-chan struct{}) {
+	go c.Informer.Run(stopCh)
+}
+
+// Describe implements prometheus.Collector interface
+func (c *VolumeReplicationCollector) Describe(ch chan<- *prometheus.Desc) {
+	ds := []*prometheus.Desc{
+		c.VolumeReplicationState,
+		c.VolumeReplicationDegraded,
+	}
+
+	for _, d := range ds {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector interface
+func (c *VolumeReplicationCollector) Collect(ch chan<- prometheus.Metric) {
+	volumeReplications := getAllVolumeReplications(c.Informer.GetIndexer(), c.AllowedNamespaces)
+	if len(volumeReplications) > 0 {
+		c.collectVolumeReplicationState(volumeReplications, ch)
+	}
+}
+
+func getAllVolumeReplications(indexer cache.Indexer, namespaces []string) (volumeReplications []*unstructured.Unstructured) {
+	var objs []interface{}
+	if len(namespaces) == 0 {
+		objs = indexer.List()
+	}
+	for _, ns := range namespaces {
+		nsObjs, err := indexer.ByIndex(cache.NamespaceIndex, ns)
+		if err != nil {
+			klog.Errorf("couldn't list VolumeReplications in namespace %s. %v", ns, err)
+			continue
+		}
+		objs = append(objs, nsObjs...)
+	}
+	for _, obj := range objs {
+		if vr, ok := obj.(*unstructured.Unstructured); ok {
+			volumeReplications = append(volumeReplications, vr)
+		}
+	}
+	return
+}
+
+func (c *VolumeReplicationCollector) collectVolumeReplicationState(volumeReplications []*unstructured.Unstructured, ch chan<- prometheus.Metric) {
+	for _, vr := range volumeReplications {
+		state, _, _ := unstructured.NestedString(vr.Object, "status", "state")
+		if state == "" {
+			state = "Unknown"
+		}
+		ch <- prometheus.MustNewConstMetric(c.VolumeReplicationState,
+			prometheus.GaugeValue, 1,
+			vr.GetName(),
+			vr.GetNamespace(),
+			state)
+
+		degraded := float64(0)
+		if getConditionStatus(vr, "Degraded") == string(metav1.ConditionTrue) {
+			degraded = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.VolumeReplicationDegraded,
+			prometheus.GaugeValue, degraded,
+			vr.GetName(),
+			vr.GetNamespace())
+	}
+}
+
+func getConditionStatus(obj *unstructured.Unstructured, conditionType string) string {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != conditionType {
+			continue
+		}
+		status, _ := condition["status"].(string)
+		return status
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func getMockVolumeReplicationCollector(t *testing.T) *VolumeReplicationCollector {
+	setKubeConfig(t)
+	mockVolumeReplicationCollector := NewVolumeReplicationCollector(mockOpts)
+	assert.NotNil(t, mockVolumeReplicationCollector)
+	return mockVolumeReplicationCollector
+}
+
+func getMockVolumeReplication(name, namespace, state, degraded string) *unstructured.Unstructured {
+	vr := &unstructured.Unstructured{}
+	vr.SetAPIVersion("replication.storage.openshift.io/v1alpha1")
+	vr.SetKind("VolumeReplication")
+	vr.SetName(name)
+	vr.SetNamespace(namespace)
+	status := map[string]interface{}{}
+	if state != "" {
+		status["state"] = state
+	}
+	if degraded != "" {
+		status["conditions"] = []interface{}{
+			map[string]interface{}{"type": "Completed", "status": "True"},
+			map[string]interface{}{"type": "Degraded", "status": degraded},
+		}
+	}
+	vr.Object["status"] = status
+	return vr
+}
+
+func TestNewVolumeReplicationCollector(t *testing.T) {
+	got := getMockVolumeReplicationCollector(t)
+	assert.NotNil(t, got.AllowedNamespaces)
+	assert.NotNil(t, got.Informer)
+}
+
+func TestGetAllVolumeReplications(t *testing.T) {
+	volumeReplicationCollector := getMockVolumeReplicationCollector(t)
+
+	vr1 := getMockVolumeReplication("vr-1", "openshift-storage", "Primary", "")
+	vr2 := getMockVolumeReplication("vr-2", "other", "Primary", "")
+	store := volumeReplicationCollector.Informer.GetStore()
+	for _, obj := range []runtime.Object{vr1, vr2} {
+		assert.Nil(t, store.Add(obj))
+	}
+
+	got := getAllVolumeReplications(volumeReplicationCollector.Informer.GetIndexer(), volumeReplicationCollector.AllowedNamespaces)
+	assert.Equal(t, []*unstructured.Unstructured{vr1}, got)
+
+	got = getAllVolumeReplications(volumeReplicationCollector.Informer.GetIndexer(), nil)
+	assert.Len(t, got, 2)
+}
+
+func TestCollectVolumeReplication(t *testing.T) {
+	volumeReplicationCollector := getMockVolumeReplicationCollector(t)
+
+	vrPrimary := getMockVolumeReplication("vr-primary", "openshift-storage", "Primary", "False")
+	vrDegraded := getMockVolumeReplication("vr-degraded", "openshift-storage", "Secondary", "True")
+	vrNew := getMockVolumeReplication("vr-new", "openshift-storage", "", "")
+	volumeReplications := []*unstructured.Unstructured{vrPrimary, vrDegraded, vrNew}
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		volumeReplicationCollector.collectVolumeReplicationState(volumeReplications, ch)
+		close(ch)
+	}()
+	states := map[string]string{}
+	degraded := map[string]float64{}
+	for m := range ch {
+		labels, value := metricLabels(t, m)
+		if _, ok := labels["state"]; ok {
+			assert.Equal(t, float64(1), value)
+			states[labels["name"]] = labels["state"]
+		} else {
+			degraded[labels["name"]] = value
+		}
+	}
+	assert.Equal(t, map[string]string{"vr-primary": "Primary", "vr-degraded": "Secondary", "vr-new": "Unknown"}, states)
+	assert.Equal(t, map[string]float64{"vr-primary": 0, "vr-degraded": 1, "vr-new": 0}, degraded)
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/red-hat-storage/ocs-operator/must-gather/runner"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+const reportFileName = "gather-collector-report.json"
+
+// task is a single unit of collection work
+type task struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// failedTask returns a task that fails with err, for the work that couldn't
+// be set up, so that it is still reported
+func failedTask(name string, err error) task {
+	return task{
+		name: name,
+		run: func(ctx context.Context) error {
+			return err
+		},
+	}
+}
+
+// Result records the outcome of a single task
+type Result struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Collector fetches the items of a Config concurrently and writes them
+// under destDir using the same layout as `oc adm inspect`.
+type Collector struct {
+	dynamicClient dynamic.Interface
+	kubeClient    kubernetes.Interface
+	executor      runner.Executor
+	destDir       string
+	concurrency   int
+	timeout       time.Duration
+	logsSince     time.Duration
+}
+
+// NewCollector returns a new Collector. The executor is used to run the ceph
+// commands in the tools pod.
+func NewCollector(dynamicClient dynamic.Interface, kubeClient kubernetes.Interface, executor runner.Executor, destDir string,
+	concurrency int, timeout, logsSince time.Duration) *Collector {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Collector{
+		dynamicClient: dynamicClient,
+		kubeClient:    kubeClient,
+		executor:      executor,
+		destDir:       destDir,
+		concurrency:   concurrency,
+		timeout:       timeout,
+		logsSince:     logsSince,
+	}
+}
+
+// Run collects everything described by config and returns the per-task
+// results. The results are also written to the report file in destDir.
+func (c *Collector) Run(ctx context.Context, config *Config) ([]Result, error) {
+	tasks := c.resourceTasks(config.Resources)
+	tasks = append(tasks, c.commandTasks(config.Commands)...)
+
+	// pod logs need a listing first, which is itself bounded by the timeout
+	for _, ns := range config.PodLogs {
+		logTasks, err := c.podLogTasks(ctx, ns)
+		if err != nil {
+			tasks = append(tasks, failedTask("logs in namespace "+ns, fmt.Errorf("failed to list pods. %v", err)))
+			continue
+		}
+		tasks = append(tasks, logTasks...)
+	}
+
+	var cephRunner *runner.Runner
+	var cephResults []runner.Result
+	if config.Ceph != nil && len(config.Ceph.Commands) > 0 {
+		var cephTasks []task
+		var err error
+		cephRunner, cephTasks, err = c.cephTasks(ctx, config.Ceph, &cephResults)
+		if err != nil {
+			cephTasks = []task{failedTask("ceph commands", fmt.Errorf("skipping ceph commands. %v", err))}
+		}
+		tasks = append(tasks, cephTasks...)
+	}
+
+	results := c.runTasks(ctx, tasks)
+
+	if cephRunner != nil {
+		err := cephRunner.WriteManifest(cephResults)
+		if err != nil {
+			klog.Errorf("failed to write ceph command manifest. %v", err)
+		}
+	}
+
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return results, fmt.Errorf("failed to marshal collector report. %v", err)
+	}
+	return results, writeFile(filepath.Join(c.destDir, reportFileName), data)
+}
+
+// runTasks runs the tasks on a bounded pool of workers. Results are
+// returned in the same order as tasks.
+func (c *Collector) runTasks(ctx context.Context, tasks []task) []Result {
+	results := make([]Result, len(tasks))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				results[idx] = c.runTask(ctx, tasks[idx])
+			}
+		}()
+	}
+
+	for i := range tasks {
+		if ctx.Err() != nil {
+			results[i] = Result{Name: tasks[i].name, Error: ctx.Err().Error()}
+			continue
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+func (c *Collector) runTask(ctx context.Context, t task) Result {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := t.run(ctx)
+	result := Result{Name: t.name, Duration: time.Since(start)}
+	if err != nil {
+		klog.Errorf("failed to collect %s. %v", t.name, err)
+		result.Error = err.Error()
+	} else {
+		klog.Infof("collected %s", t.name)
+	}
+	return result
+}
+
+func (c *Collector) resourceTasks(resources []ResourceSpec) []task {
+	tasks := []task{}
+	for _, r := range resources {
+		r := r
+		switch {
+		case r.ClusterScoped():
+			tasks = append(tasks, task{
+				name: r.Resource,
+				run: func(ctx context.Context) error {
+					return c.collectResource(ctx, r, metav1.NamespaceAll)
+				},
+			})
+		case r.AllNamespaces:
+			tasks = append(tasks, task{
+				name: r.Resource + " in all namespaces",
+				run: func(ctx context.Context) error {
+					return c.collectResource(ctx, r, metav1.NamespaceAll)
+				},
+			})
+		default:
+			for _, ns := range r.Namespaces {
+				ns := ns
+				tasks = append(tasks, task{
+					name: fmt.Sprintf("%s in namespace %s", r.Resource, ns),
+					run: func(ctx context.Context) error {
+						return c.collectResource(ctx, r, ns)
+					},
+				})
+			}
+		}
+	}
+	return tasks
+}
+
+// collectResource lists the resource in namespace and writes the items,
+// grouped by their namespace, as a YAML List.
+func (c *Collector) collectResource(ctx context.Context, r ResourceSpec, namespace string) error {
+	client := c.dynamicClient.Resource(r.GroupVersionResource())
+	var list *unstructured.UnstructuredList
+	var err error
+	if r.ClusterScoped() {
+		list, err = client.List(ctx, metav1.ListOptions{})
+	} else {
+		list, err = client.Namespace(namespace).List(ctx, metav1.ListOptions{})
+	}
+	if err != nil {
+		return err
+	}
+
+	byNamespace := map[string][]interface{}{}
+	if !r.ClusterScoped() && namespace != metav1.NamespaceAll {
+		// always write the namespace file, even when it has no items
+		byNamespace[namespace] = []interface{}{}
+	}
+	for _, item := range list.Items {
+		ns := item.GetNamespace()
+		byNamespace[ns] = append(byNamespace[ns], item.Object)
+	}
+
+	for ns, items := range byNamespace {
+		data, err := yaml.Marshal(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "List",
+			"items":      items,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s. %v", r.Resource, err)
+		}
+		err = writeFile(resourcePath(c.destDir, r, ns), data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Collector) podLogTasks(ctx context.Context, namespace string) ([]task, error) {
+	listCtx := ctx
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		listCtx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	pods, err := c.kubeClient.CoreV1().Pods(namespace).List(listCtx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []task{}
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			pod, container := pod.Name, container.Name
+			tasks = append(tasks, task{
+				name: fmt.Sprintf("logs of %s/%s container %s", namespace, pod, container),
+				run: func(ctx context.Context) error {
+					return c.collectPodLogs(ctx, namespace, pod, container)
+				},
+			})
+		}
+	}
+	return tasks, nil
+}
+
+func (c *Collector) collectPodLogs(ctx context.Context, namespace, pod, container string) error {
+	opts := &corev1.PodLogOptions{Container: container}
+	if c.logsSince > 0 {
+		seconds := int64(c.logsSince.Seconds())
+		opts.SinceSeconds = &seconds
+	}
+	data, err := c.kubeClient.CoreV1().Pods(namespace).GetLogs(pod, opts).DoRaw(ctx)
+	if err != nil {
+		return err
+	}
+	return writeFile(podLogPath(c.destDir, namespace, pod, container), data)
+}
+
+// cephTasks returns a task per ceph command, run in the first running tools
+// pod. Each task appends its runner.Result to results.
+func (c *Collector) cephTasks(ctx context.Context, spec *CephSpec, results *[]runner.Result) (*runner.Runner, []task, error) {
+	pod, err := c.findRunningPod(ctx, spec.Namespace, spec.PodSelector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the runner applies the per command timeout itself
+	r := runner.NewRunner(c.executor, spec.Namespace, pod, spec.Container, filepath.Join(c.destDir, "ceph"),
+		c.timeout, spec.MaxOutputBytes, spec.PreferJSON)
+
+	var mu sync.Mutex
+	tasks := []task{}
+	for _, cmd := range spec.Commands {
+		cmd := cmd
+		tasks = append(tasks, task{
+			name: fmt.Sprintf("ceph command %q", strings.Join(cmd.Command, " ")),
+			run: func(ctx context.Context) error {
+				result := r.Run(ctx, cmd)
+				mu.Lock()
+				*results = append(*results, result)
+				mu.Unlock()
+				if result.Error != "" {
+					return fmt.Errorf("exit code %d: %s", result.ExitCode, result.Error)
+				}
+				return nil
+			},
+		})
+	}
+	return r, tasks, nil
+}
+
+func (c *Collector) findRunningPod(ctx context.Context, namespace, selector string) (string, error) {
+	pods, err := c.kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods with selector %q. %v", selector, err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning {
+			return pod.Name, nil
+		}
+	}
+	return "", fmt.Errorf("no running pod with selector %q in namespace %q", selector, namespace)
+}
+
+func (c *Collector) commandTasks(commands []CommandSpec) []task {
+	tasks := []task{}
+	for _, cmd := range commands {
+		cmd := cmd
+		tasks = append(tasks, task{
+			name: fmt.Sprintf("command %q", strings.Join(cmd.Command, " ")),
+			run: func(ctx context.Context) error {
+				return c.collectCommand(ctx, cmd)
+			},
+		})
+	}
+	return tasks
+}
+
+func (c *Collector) collectCommand(ctx context.Context, cmd CommandSpec) error {
+	var stdout, stderr bytes.Buffer
+	command := exec.CommandContext(ctx, cmd.Command[0], cmd.Command[1:]...) // #nosec G204
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	err := command.Run()
+	// keep whatever was produced, the error is reported separately
+	if writeErr := writeFile(commandPath(c.destDir, cmd), stdout.Bytes()); writeErr != nil {
+		return writeErr
+	}
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// failedResults returns the names of the failed results, sorted
+func failedResults(results []Result) []string {
+	failed := []string{}
+	for _, r := range results {
+		if r.Error != "" {
+			failed = append(failed, r.Name)
+		}
+	}
+	sort.Strings(failed)
+	return failed
+}
+
+// resourcePath follows the `oc adm inspect` layout, where core resources
+// live under the "core" group directory.
+func resourcePath(destDir string, r ResourceSpec, namespace string) string {
+	group := r.Group
+	if group == "" {
+		group = "core"
+	}
+	if r.ClusterScoped() {
+		return filepath.Join(destDir, "cluster-scoped-resources", group, r.Resource+".yaml")
+	}
+	return filepath.Join(destDir, "namespaces", namespace, group, r.Resource+".yaml")
+}
+
+func podLogPath(destDir, namespace, pod, container string) string {
+	return filepath.Join(destDir, "namespaces", namespace, "pods", pod, container, container, "logs", "current.log")
+}
+
+func commandPath(destDir string, cmd CommandSpec) string {
+	if cmd.Namespace == "" {
+		return filepath.Join(destDir, "oc_output", cmd.Name)
+	}
+	return filepath.Join(destDir, "namespaces", cmd.Namespace, "oc_output", cmd.Name)
+}
+
+func writeFile(path string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	config, err := loadConfig("")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, config.Resources)
+
+	dir, err := ioutil.TempDir("", "collector")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cases := []struct {
+		label     string
+		content   string
+		expectErr bool
+	}{
+		{
+			label: "valid config",
+			content: `
+resources:
+- group: ceph.rook.io
+  version: v1
+  resource: cephclusters
+  namespaces: [openshift-storage]
+podLogs: [openshift-storage]
+commands:
+- name: pods
+  command: [oc, get, pods]
+`,
+		},
+		{
+			label: "resource without version",
+			content: `
+resources:
+- resource: pods
+`,
+			expectErr: true,
+		},
+		{
+			label: "both namespaces and allNamespaces",
+			content: `
+resources:
+- version: v1
+  resource: pods
+  namespaces: [openshift-storage]
+  allNamespaces: true
+`,
+			expectErr: true,
+		},
+		{
+			label: "duplicate command names",
+			content: `
+commands:
+- name: pods
+  command: [oc, get, pods]
+- name: pods
+  command: [oc, describe, pods]
+`,
+			expectErr: true,
+		},
+	}
+
+	for i, c := range cases {
+		t.Logf("Case %d: %s", i+1, c.label)
+		path := filepath.Join(dir, "config.yaml")
+		assert.NoError(t, ioutil.WriteFile(path, []byte(c.content), 0644))
+		_, err := loadConfig(path)
+		if c.expectErr {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestResourcePath(t *testing.T) {
+	cases := []struct {
+		spec      ResourceSpec
+		namespace string
+		expected  string
+	}{
+		{
+			spec:      ResourceSpec{Version: "v1", Resource: "pods", Namespaces: []string{"ns"}},
+			namespace: "ns",
+			expected:  "dest/namespaces/ns/core/pods.yaml",
+		},
+		{
+			spec:      ResourceSpec{Group: "ceph.rook.io", Version: "v1", Resource: "cephblockpools", AllNamespaces: true},
+			namespace: "other",
+			expected:  "dest/namespaces/other/ceph.rook.io/cephblockpools.yaml",
+		},
+		{
+			spec:     ResourceSpec{Group: "storage.k8s.io", Version: "v1", Resource: "storageclasses"},
+			expected: "dest/cluster-scoped-resources/storage.k8s.io/storageclasses.yaml",
+		},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, resourcePath("dest", c.spec, c.namespace))
+	}
+}
+
+func TestRunTasks(t *testing.T) {
+	collector := NewCollector(nil, nil, nil, "", 2, 50*time.Millisecond, 0)
+
+	var running, maxRunning int32
+	work := func(ctx context.Context) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	}
+
+	tasks := []task{
+		{name: "a", run: work},
+		{name: "b", run: func(ctx context.Context) error { return errors.New("failed") }},
+		{name: "c", run: func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() }},
+		{name: "d", run: work},
+		{name: "e", run: work},
+		failedTask("f", errors.New("failed to list pods")),
+	}
+
+	results := collector.runTasks(context.TODO(), tasks)
+	assert.Len(t, results, len(tasks))
+	for i, r := range results {
+		assert.Equal(t, tasks[i].name, r.Name)
+	}
+	assert.Equal(t, []string{"b", "c", "f"}, failedResults(results))
+	assert.Equal(t, "failed to list pods", results[5].Error)
+	assert.LessOrEqual(t, maxRunning, int32(2))
+}
+
+func TestCollectCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "collector")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	collector := NewCollector(nil, nil, nil, dir, 1, time.Second, 0)
+	cmd := CommandSpec{Name: "echo", Namespace: "ns", Command: []string{"echo", "hello"}}
+	assert.NoError(t, collector.collectCommand(context.TODO(), cmd))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "namespaces", "ns", "oc_output", "echo"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello\n", string(data))
+
+	cmd = CommandSpec{Name: "false", Command: []string{"false"}}
+	assert.Error(t, collector.collectCommand(context.TODO(), cmd))
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ghodss/yaml"
+	"github.com/red-hat-storage/ocs-operator/must-gather/runner"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	installNamespace = "openshift-storage"
+	ramenNamespace   = "openshift-dr-system"
+	toolsPodSelector = "app=rook-ceph-tools"
+)
+
+// Config is the declarative description of what the collector gathers.
+type Config struct {
+	// Resources are the custom and core resources to be dumped as YAML
+	Resources []ResourceSpec `json:"resources,omitempty"`
+	// PodLogs lists the namespaces whose pod logs are collected
+	PodLogs []string `json:"podLogs,omitempty"`
+	// Commands are external commands whose output is collected
+	Commands []CommandSpec `json:"commands,omitempty"`
+	// Ceph describes the commands run inside the ceph tools pod
+	Ceph *CephSpec `json:"ceph,omitempty"`
+}
+
+// ResourceSpec identifies a resource by its GroupVersionResource and the
+// namespaces it should be collected from. A resource without namespaces
+// and without AllNamespaces is treated as cluster scoped.
+type ResourceSpec struct {
+	Group         string   `json:"group,omitempty"`
+	Version       string   `json:"version"`
+	Resource      string   `json:"resource"`
+	Namespaces    []string `json:"namespaces,omitempty"`
+	AllNamespaces bool     `json:"allNamespaces,omitempty"`
+}
+
+// CommandSpec is an external command whose stdout is written to
+// <dest-dir>/namespaces/<namespace>/oc_output/<name>, or to
+// <dest-dir>/oc_output/<name> when no namespace is given.
+type CommandSpec struct {
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace,omitempty"`
+	Command   []string `json:"command"`
+}
+
+// CephSpec describes the ceph, rbd and rados commands run inside the ceph
+// tools pod. Their output is written to <dest-dir>/ceph.
+type CephSpec struct {
+	Namespace string `json:"namespace,omitempty"`
+	// PodSelector is the label selector of the tools pod
+	PodSelector string `json:"podSelector,omitempty"`
+	Container   string `json:"container,omitempty"`
+	// MaxOutputBytes caps the output kept per command
+	MaxOutputBytes int64 `json:"maxOutputBytes,omitempty"`
+	// PreferJSON adds `--format json-pretty` to commands that support it
+	PreferJSON bool             `json:"preferJSON,omitempty"`
+	Commands   []runner.Command `json:"commands,omitempty"`
+}
+
+// GroupVersionResource returns the schema.GroupVersionResource of the spec
+func (r ResourceSpec) GroupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: r.Group, Version: r.Version, Resource: r.Resource}
+}
+
+// ClusterScoped returns true if the resource is collected at the cluster level
+func (r ResourceSpec) ClusterScoped() bool {
+	return len(r.Namespaces) == 0 && !r.AllNamespaces
+}
+
+func (c *Config) validate() error {
+	for _, r := range c.Resources {
+		if r.Version == "" || r.Resource == "" {
+			return fmt.Errorf("resource %q in group %q must set both version and resource", r.Resource, r.Group)
+		}
+		if r.AllNamespaces && len(r.Namespaces) > 0 {
+			return fmt.Errorf("resource %q must not set both namespaces and allNamespaces", r.Resource)
+		}
+	}
+	names := map[string]bool{}
+	for _, cmd := range c.Commands {
+		if cmd.Name == "" || len(cmd.Command) == 0 {
+			return fmt.Errorf("command %v must set both name and command", cmd.Command)
+		}
+		key := cmd.Namespace + "/" + cmd.Name
+		if names[key] {
+			return fmt.Errorf("duplicate command name %q", key)
+		}
+		names[key] = true
+	}
+	if c.Ceph != nil {
+		for _, cmd := range c.Ceph.Commands {
+			if len(cmd.Command) == 0 {
+				return fmt.Errorf("ceph command %q must set command", cmd.Name)
+			}
+		}
+		if c.Ceph.Namespace == "" {
+			c.Ceph.Namespace = installNamespace
+		}
+		if c.Ceph.PodSelector == "" {
+			c.Ceph.PodSelector = toolsPodSelector
+		}
+	}
+	return nil
+}
+
+// loadConfig reads the config at path, or returns the default config when
+// path is empty.
+func loadConfig(path string) (*Config, error) {
+	if path == "" {
+		config := defaultConfig()
+		return config, config.validate()
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %q. %v", path, err)
+	}
+
+	config := &Config{}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %q. %v", path, err)
+	}
+
+	return config, config.validate()
+}
+
+// defaultConfig mirrors what the gather_*_resources scripts collect
+func defaultConfig() *Config {
+	ns := []string{installNamespace}
+	return &Config{
+		Resources: []ResourceSpec{
+			{Group: "ocs.openshift.io", Version: "v1", Resource: "storageclusters", Namespaces: ns},
+			{Group: "ocs.openshift.io", Version: "v1", Resource: "ocsinitializations", Namespaces: ns},
+			{Group: "ocs.openshift.io", Version: "v1alpha1", Resource: "storageconsumers", Namespaces: ns},
+			{Group: "odf.openshift.io", Version: "v1alpha1", Resource: "storagesystems", Namespaces: ns},
+			{Group: "ceph.rook.io", Version: "v1", Resource: "cephclusters", Namespaces: ns},
+			{Group: "ceph.rook.io", Version: "v1", Resource: "cephblockpools", AllNamespaces: true},
+			{Group: "ceph.rook.io", Version: "v1", Resource: "cephfilesystems", AllNamespaces: true},
+			{Group: "ceph.rook.io", Version: "v1", Resource: "cephobjectstores", Namespaces: ns},
+			{Group: "ceph.rook.io", Version: "v1", Resource: "cephobjectstoreusers", Namespaces: ns},
+			{Group: "noobaa.io", Version: "v1alpha1", Resource: "noobaas", Namespaces: ns},
+			{Group: "noobaa.io", Version: "v1alpha1", Resource: "backingstores", Namespaces: ns},
+			{Group: "noobaa.io", Version: "v1alpha1", Resource: "bucketclasses", Namespaces: ns},
+			{Group: "objectbucket.io", Version: "v1alpha1", Resource: "objectbuckets"},
+			{Group: "objectbucket.io", Version: "v1alpha1", Resource: "objectbucketclaims", AllNamespaces: true},
+			{Group: "replication.storage.openshift.io", Version: "v1alpha1", Resource: "volumereplications", AllNamespaces: true},
+			{Group: "operators.coreos.com", Version: "v1alpha1", Resource: "subscriptions", Namespaces: ns},
+			{Group: "operators.coreos.com", Version: "v1alpha1", Resource: "clusterserviceversions", Namespaces: ns},
+			{Group: "operators.coreos.com", Version: "v1alpha1", Resource: "installplans", Namespaces: ns},
+			{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshotclasses"},
+			{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshotcontents"},
+			{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshots", AllNamespaces: true},
+			{Group: "storage.k8s.io", Version: "v1", Resource: "storageclasses"},
+			{Version: "v1", Resource: "persistentvolumes"},
+			{Version: "v1", Resource: "persistentvolumeclaims", AllNamespaces: true},
+			{Version: "v1", Resource: "pods", Namespaces: []string{installNamespace, ramenNamespace}},
+			{Version: "v1", Resource: "configmaps", Namespaces: []string{installNamespace, ramenNamespace}},
+			{Version: "v1", Resource: "events", Namespaces: ns},
+			{Group: "apps", Version: "v1", Resource: "deployments", Namespaces: ns},
+			{Group: "apps", Version: "v1", Resource: "statefulsets", Namespaces: ns},
+		},
+		PodLogs: ns,
+		Commands: []CommandSpec{
+			{Name: "pods_-owide", Namespace: installNamespace, Command: []string{"oc", "get", "pods", "-owide", "-n", installNamespace}},
+			{Name: "all_-o_wide", Namespace: installNamespace, Command: []string{"oc", "get", "all", "-o", "wide", "-n", installNamespace}},
+			{Name: "events", Namespace: installNamespace, Command: []string{"oc", "get", "events", "-n", installNamespace}},
+			{Name: "storagecluster", Namespace: installNamespace, Command: []string{"oc", "describe", "storagecluster", "-n", installNamespace}},
+		},
+		Ceph: &CephSpec{
+			Namespace:   installNamespace,
+			PodSelector: toolsPodSelector,
+			PreferJSON:  true,
+			Commands: []runner.Command{
+				{Command: []string{"ceph", "status"}},
+				{Command: []string{"ceph", "health", "detail"}},
+				{Command: []string{"ceph", "versions"}},
+				{Command: []string{"ceph", "df", "detail"}},
+				{Command: []string{"ceph", "osd", "tree"}},
+				{Command: []string{"ceph", "osd", "df", "tree"}},
+				{Command: []string{"ceph", "osd", "dump"}},
+				{Command: []string{"ceph", "osd", "pool", "ls", "detail"}},
+				{Command: []string{"ceph", "osd", "crush", "dump"}},
+				{Command: []string{"ceph", "mon", "dump"}},
+				{Command: []string{"ceph", "mgr", "dump"}},
+				{Command: []string{"ceph", "fs", "dump"}},
+				{Command: []string{"ceph", "fs", "ls"}},
+				{Command: []string{"ceph", "pg", "dump"}},
+				{Command: []string{"ceph", "config", "dump"}},
+				{Command: []string{"ceph", "crash", "ls"}},
+				{Command: []string{"rados", "lspools"}},
+			},
+		},
+	}
+}
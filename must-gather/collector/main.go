@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/red-hat-storage/ocs-operator/must-gather/runner"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog"
+)
+
+var (
+	configPath  = flag.String("config", "", "Path to a YAML file describing what to collect. The built-in list is used when empty")
+	destDir     = flag.String("dest-dir", "must-gather", "Directory the collected data is written to")
+	kubeconfig  = flag.String("kubeconfig", os.Getenv("KUBECONFIG"), "Absolute path to the kubeconfig file. In-cluster config is used when empty")
+	concurrency = flag.Int("concurrency", 8, "Maximum number of items collected in parallel")
+	timeout     = flag.Duration("timeout", 2*time.Minute, "Timeout for collecting a single item")
+	since       = flag.Duration("since", 0, "Only collect pod logs newer than this duration. All logs are collected when 0")
+	useOcExec   = flag.Bool("oc-exec", false, "Run the ceph commands through `oc exec` instead of the Kubernetes exec API")
+	cephPod     = flag.String("ceph-pod-selector", "", "Label selector of the pod the ceph commands are run in. The one of the config is used when empty")
+)
+
+func main() {
+	klog.InitFlags(nil)
+	flag.Parse()
+
+	config, err := loadConfig(*configPath)
+	if err != nil {
+		klog.Fatalf("invalid collector config. %v", err)
+	}
+	if *cephPod != "" && config.Ceph != nil {
+		config.Ceph.PodSelector = *cephPod
+	}
+
+	restConfig, err := clientcmd.BuildConfigFromFlags("", *kubeconfig)
+	if err != nil {
+		klog.Fatalf("failed to create cluster config. %v", err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		klog.Fatalf("failed to create dynamic client. %v", err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		klog.Fatalf("failed to create kubernetes client. %v", err)
+	}
+
+	var executor runner.Executor = runner.OcExecutor{}
+	if !*useOcExec {
+		executor, err = runner.NewPodExecutor(restConfig)
+		if err != nil {
+			klog.Fatalf("failed to create pod executor. %v", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	start := time.Now()
+	collector := NewCollector(dynamicClient, kubeClient, executor, *destDir, *concurrency, *timeout, *since)
+	results, err := collector.Run(ctx, config)
+	if err != nil {
+		klog.Errorf("failed to write collector report. %v", err)
+	}
+
+	failed := failedResults(results)
+	klog.Infof("collected %d/%d items in %v", len(results)-len(failed), len(results), time.Since(start))
+	for _, name := range failed {
+		klog.Warningf("failed to collect %s", name)
+	}
+	if len(failed) > 0 || err != nil {
+		klog.Flush()
+		os.Exit(1)
+	}
+}
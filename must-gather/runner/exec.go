@@ -0,0 +1,131 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// killAfter is how long a command that outlived its deadline is given to
+// exit after SIGTERM, before it is killed
+const killAfter = 5 * time.Second
+
+// PodExecutor runs commands through the Kubernetes exec API
+type PodExecutor struct {
+	config *rest.Config
+	client kubernetes.Interface
+}
+
+// NewPodExecutor returns a new PodExecutor for the cluster of config
+func NewPodExecutor(config *rest.Config) (*PodExecutor, error) {
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client. %v", err)
+	}
+	return &PodExecutor{config: config, client: client}, nil
+}
+
+// Exec implements Executor. The command is bounded inside the pod by the
+// deadline of ctx, so that it does not keep running once Exec has returned.
+func (e *PodExecutor) Exec(ctx context.Context, namespace, pod, container string, command []string, stdout, stderr io.Writer) error {
+	req := e.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod).
+		Namespace(namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   boundedCommand(ctx, command),
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(e.config, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create executor for pod %s/%s. %v", namespace, pod, err)
+	}
+
+	// the stream can't be cancelled, so stop forwarding its output once ctx
+	// is done and leave the stream to end with the command in the pod
+	outWriter := &cancelableWriter{w: stdout}
+	errWriter := &cancelableWriter{w: stderr}
+	done := make(chan error, 1)
+	go func() {
+		done <- executor.Stream(remotecommand.StreamOptions{Stdout: outWriter, Stderr: errWriter})
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		outWriter.cancel()
+		errWriter.cancel()
+		return ctx.Err()
+	}
+}
+
+// OcExecutor runs commands through `oc exec`, as the gather scripts do. It
+// is kept as a fallback for environments where the exec API can't be
+// reached directly.
+type OcExecutor struct{}
+
+// Exec implements Executor
+func (OcExecutor) Exec(ctx context.Context, namespace, pod, container string, command []string, stdout, stderr io.Writer) error {
+	args := []string{"-n", namespace, "exec", pod}
+	if container != "" {
+		args = append(args, "-c", container)
+	}
+	args = append(args, "--")
+	args = append(args, boundedCommand(ctx, command)...)
+
+	cmd := exec.CommandContext(ctx, "oc", args...) // #nosec G204
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return cmd.Run()
+}
+
+// boundedCommand wraps command with `timeout` when ctx has a deadline, as
+// cancelling the exec session doesn't stop the command in the container
+func boundedCommand(ctx context.Context, command []string) []string {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return command
+	}
+	seconds := int64(math.Ceil(time.Until(deadline).Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return append([]string{"timeout", fmt.Sprintf("--kill-after=%ds", int64(killAfter.Seconds())), fmt.Sprintf("%ds", seconds)}, command...)
+}
+
+// cancelableWriter forwards writes to w until it is cancelled
+type cancelableWriter struct {
+	mu        sync.Mutex
+	w         io.Writer
+	cancelled bool
+}
+
+func (c *cancelableWriter) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cancelled {
+		return len(p), nil
+	}
+	return c.w.Write(p)
+}
+
+func (c *cancelableWriter) cancel() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cancelled = true
+}
@@ -0,0 +1,221 @@
+// Package runner executes ceph, rbd and rados commands inside the ceph
+// tools pod, bounding both the time they may take and the amount of output
+// that is kept.
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxOutputBytes is the default cap on the output kept per command
+	DefaultMaxOutputBytes = 64 * 1024 * 1024
+
+	// ManifestFileName is the name of the manifest written by WriteManifest
+	ManifestFileName = "manifest.json"
+
+	outputDir     = "must_gather_commands"
+	jsonOutputDir = "must_gather_commands_json_output"
+	jsonSuffix    = "_--format_json-pretty"
+)
+
+// jsonCapable are the tools that accept `--format json-pretty`
+var jsonCapable = map[string]bool{
+	"ceph":  true,
+	"rbd":   true,
+	"rados": true,
+}
+
+// Executor runs a command inside a container of a pod
+type Executor interface {
+	Exec(ctx context.Context, namespace, pod, container string, command []string, stdout, stderr io.Writer) error
+}
+
+// Command is a single command to be run in the tools pod
+type Command struct {
+	// Name is used as the output file name. It defaults to the command
+	// joined by underscores, as done by the gather scripts.
+	Name string `json:"name,omitempty"`
+	// Command is the command and its arguments, e.g. ["ceph", "status"]
+	Command []string `json:"command"`
+}
+
+// Result records the outcome of a single command
+type Result struct {
+	Name      string        `json:"name"`
+	Command   []string      `json:"command"`
+	Output    string        `json:"output"`
+	ExitCode  int           `json:"exitCode"`
+	Bytes     int64         `json:"bytes"`
+	Truncated bool          `json:"truncated,omitempty"`
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// Runner runs commands in a given container and writes their output under
+// destDir, using the same layout as the gather_ceph_resources script.
+type Runner struct {
+	executor       Executor
+	namespace      string
+	pod            string
+	container      string
+	destDir        string
+	timeout        time.Duration
+	maxOutputBytes int64
+	preferJSON     bool
+}
+
+// NewRunner returns a new Runner. A timeout of 0 disables the per-command
+// deadline, and a maxOutputBytes of 0 uses DefaultMaxOutputBytes.
+func NewRunner(executor Executor, namespace, pod, container, destDir string,
+	timeout time.Duration, maxOutputBytes int64, preferJSON bool) *Runner {
+	if maxOutputBytes <= 0 {
+		maxOutputBytes = DefaultMaxOutputBytes
+	}
+	return &Runner{
+		executor:       executor,
+		namespace:      namespace,
+		pod:            pod,
+		container:      container,
+		destDir:        destDir,
+		timeout:        timeout,
+		maxOutputBytes: maxOutputBytes,
+		preferJSON:     preferJSON,
+	}
+}
+
+// Run runs cmd and writes its output. The returned Result is always
+// populated, even when the command fails.
+func (r *Runner) Run(ctx context.Context, cmd Command) Result {
+	command, isJSON := r.commandLine(cmd.Command)
+	name := cmd.Name
+	if name == "" {
+		name = strings.Join(cmd.Command, "_")
+	}
+
+	output := filepath.Join(r.destDir, outputDir, name)
+	if isJSON {
+		output = filepath.Join(r.destDir, jsonOutputDir, name+jsonSuffix)
+	}
+	result := Result{Name: name, Command: command, Output: output}
+
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	limited := &limitWriter{w: &stdout, remaining: r.maxOutputBytes}
+	start := time.Now()
+	err := r.executor.Exec(ctx, r.namespace, r.pod, r.container, command, limited, &limitWriter{w: &stderr, remaining: 4096})
+	result.Duration = time.Since(start)
+	result.Bytes = limited.written
+	result.Truncated = limited.truncated
+
+	if err != nil {
+		result.ExitCode = exitCode(err)
+		result.Error = err.Error()
+		if ctx.Err() != nil {
+			result.Error = fmt.Sprintf("%v: %v", ctx.Err(), err)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			result.Error = fmt.Sprintf("%s: %s", result.Error, msg)
+		}
+	}
+
+	// whatever was received before a failure is still worth keeping
+	if writeErr := writeFile(output, stdout.Bytes()); writeErr != nil && result.Error == "" {
+		result.Error = writeErr.Error()
+	}
+
+	return result
+}
+
+// WriteManifest records the results in the manifest file under destDir
+func (r *Runner) WriteManifest(results []Result) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal command manifest. %v", err)
+	}
+	return writeFile(filepath.Join(r.destDir, ManifestFileName), data)
+}
+
+// commandLine returns the command to run and whether it produces JSON
+func (r *Runner) commandLine(command []string) ([]string, bool) {
+	if len(command) == 0 || !jsonCapable[command[0]] {
+		return command, false
+	}
+	for i, arg := range command {
+		if strings.HasPrefix(arg, "--format=") {
+			return command, strings.HasPrefix(strings.TrimPrefix(arg, "--format="), "json")
+		}
+		if arg == "--format" && i+1 < len(command) {
+			return command, strings.HasPrefix(command[i+1], "json")
+		}
+	}
+	if !r.preferJSON {
+		return command, false
+	}
+	return append(append([]string{}, command...), "--format", "json-pretty"), true
+}
+
+// exitCode returns the exit status carried by err, or -1 if the command
+// did not get to run to completion
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if e, ok := err.(interface{ ExitCode() int }); ok {
+		return e.ExitCode()
+	}
+	if e, ok := err.(interface{ ExitStatus() int }); ok {
+		return e.ExitStatus()
+	}
+	return -1
+}
+
+// limitWriter writes up to remaining bytes to w and silently drops the rest
+type limitWriter struct {
+	w         io.Writer
+	remaining int64
+	written   int64
+	truncated bool
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+		l.truncated = true
+	}
+	if len(p) > 0 {
+		written, err := l.w.Write(p)
+		l.written += int64(written)
+		l.remaining -= int64(written)
+		if err != nil {
+			return written, err
+		}
+	}
+	// report the full length, so that the command is not killed by a
+	// short write once the cap is reached
+	return n, nil
+}
+
+func writeFile(path string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
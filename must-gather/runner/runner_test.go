@@ -0,0 +1,160 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type exitError int
+
+func (e exitError) Error() string { return fmt.Sprintf("exit status %d", int(e)) }
+func (e exitError) ExitCode() int { return int(e) }
+
+// fakeExecutor echoes the command line, or fails/hangs for special commands
+type fakeExecutor struct {
+	commands [][]string
+}
+
+func (f *fakeExecutor) Exec(ctx context.Context, namespace, pod, container string, command []string, stdout, stderr io.Writer) error {
+	f.commands = append(f.commands, command)
+	switch command[0] {
+	case "fail":
+		fmt.Fprint(stderr, "something went wrong")
+		return exitError(22)
+	case "hang":
+		<-ctx.Done()
+		return ctx.Err()
+	case "big":
+		_, err := stdout.Write([]byte(strings.Repeat("x", 100)))
+		return err
+	}
+	_, err := fmt.Fprint(stdout, strings.Join(command, " "))
+	return err
+}
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "runner")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	executor := &fakeExecutor{}
+	r := NewRunner(executor, "openshift-storage", "tools", "", dir, 50*time.Millisecond, 10, true)
+
+	cases := []struct {
+		label            string
+		command          Command
+		expectedCommand  []string
+		expectedOutput   string
+		expectedContent  string
+		expectedExitCode int
+		expectTruncated  bool
+		expectErr        bool
+	}{
+		{
+			label:           "json format is appended to ceph commands",
+			command:         Command{Command: []string{"ceph", "df"}},
+			expectedCommand: []string{"ceph", "df", "--format", "json-pretty"},
+			expectedOutput:  filepath.Join(dir, jsonOutputDir, "ceph_df"+jsonSuffix),
+			expectedContent: "ceph df --",
+			expectTruncated: true,
+		},
+		{
+			label:           "explicit plain format is kept",
+			command:         Command{Name: "osd", Command: []string{"ceph", "osd", "ls", "--format=plain"}},
+			expectedCommand: []string{"ceph", "osd", "ls", "--format=plain"},
+			expectedOutput:  filepath.Join(dir, outputDir, "osd"),
+			expectedContent: "ceph osd l",
+			expectTruncated: true,
+		},
+		{
+			label:           "other tools are run as is",
+			command:         Command{Command: []string{"ls"}},
+			expectedCommand: []string{"ls"},
+			expectedOutput:  filepath.Join(dir, outputDir, "ls"),
+			expectedContent: "ls",
+		},
+		{
+			label:            "exit code is recorded",
+			command:          Command{Command: []string{"fail"}},
+			expectedCommand:  []string{"fail"},
+			expectedOutput:   filepath.Join(dir, outputDir, "fail"),
+			expectedExitCode: 22,
+			expectErr:        true,
+		},
+		{
+			label:            "timeout is enforced",
+			command:          Command{Command: []string{"hang"}},
+			expectedCommand:  []string{"hang"},
+			expectedOutput:   filepath.Join(dir, outputDir, "hang"),
+			expectedExitCode: -1,
+			expectErr:        true,
+		},
+		{
+			label:           "output beyond the cap is dropped",
+			command:         Command{Command: []string{"big"}},
+			expectedCommand: []string{"big"},
+			expectedOutput:  filepath.Join(dir, outputDir, "big"),
+			expectedContent: "xxxxxxxxxx",
+			expectTruncated: true,
+		},
+	}
+
+	results := []Result{}
+	for i, c := range cases {
+		t.Logf("Case %d: %s", i+1, c.label)
+		result := r.Run(context.TODO(), c.command)
+		assert.Equal(t, c.expectedCommand, result.Command)
+		assert.Equal(t, c.expectedOutput, result.Output)
+		assert.Equal(t, c.expectedExitCode, result.ExitCode)
+		assert.Equal(t, c.expectTruncated, result.Truncated)
+		assert.Equal(t, c.expectErr, result.Error != "")
+
+		data, err := ioutil.ReadFile(result.Output)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expectedContent, string(data))
+		results = append(results, result)
+	}
+
+	assert.NoError(t, r.WriteManifest(results))
+	data, err := ioutil.ReadFile(filepath.Join(dir, ManifestFileName))
+	assert.NoError(t, err)
+	manifest := []Result{}
+	assert.NoError(t, json.Unmarshal(data, &manifest))
+	assert.Len(t, manifest, len(cases))
+	assert.Equal(t, 22, manifest[3].ExitCode)
+}
+
+func TestBoundedCommand(t *testing.T) {
+	command := []string{"ceph", "status"}
+	assert.Equal(t, command, boundedCommand(context.TODO(), command))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 1500*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, []string{"timeout", "--kill-after=5s", "2s", "ceph", "status"}, boundedCommand(ctx, command))
+
+	expired, cancelExpired := context.WithTimeout(context.TODO(), -time.Second)
+	defer cancelExpired()
+	assert.Equal(t, []string{"timeout", "--kill-after=5s", "1s", "ceph", "status"}, boundedCommand(expired, command))
+}
+
+func TestCancelableWriter(t *testing.T) {
+	var buf strings.Builder
+	w := &cancelableWriter{w: &buf}
+	_, err := w.Write([]byte("before"))
+	assert.NoError(t, err)
+	w.cancel()
+	n, err := w.Write([]byte("after"))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, "before", buf.String())
+}
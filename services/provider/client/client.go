@@ -118,3 +118,39 @@ func (cc *OCSProviderClient) UpdateCapacity(ctx context.Context, consumerUUID, c
 
 	return cc.Client.UpdateCapacity(apiCtx, req)
 }
+
+// RotateOnboardingToken replaces the onboarding ticket of the StorageConsumer and rotates the
+// keys of its ceph clients on the storage provider cluster
+func (cc *OCSProviderClient) RotateOnboardingToken(ctx context.Context, consumerUUID, ticket string) (*pb.RotateOnboardingTokenResponse, error) {
+	if cc.Client == nil || cc.clientConn == nil {
+		return nil, fmt.Errorf("provider client is closed")
+	}
+
+	req := &pb.RotateOnboardingTokenRequest{
+		StorageConsumerUUID: consumerUUID,
+		OnboardingTicket:    ticket,
+	}
+
+	apiCtx, cancel := context.WithTimeout(ctx, cc.timeout)
+	defer cancel()
+
+	return cc.Client.RotateOnboardingToken(apiCtx, req)
+}
+
+// RevokeStorageConsumer revokes the access of the consumer to the storage provider cluster
+// without deleting the StorageConsumer CR. The ticket has to be signed by the provider.
+func (cc *OCSProviderClient) RevokeStorageConsumer(ctx context.Context, consumerUUID, ticket string) (*pb.RevokeStorageConsumerResponse, error) {
+	if cc.Client == nil || cc.clientConn == nil {
+		return nil, fmt.Errorf("provider client is closed")
+	}
+
+	req := &pb.RevokeStorageConsumerRequest{
+		StorageConsumerUUID: consumerUUID,
+		RevocationTicket:    ticket,
+	}
+
+	apiCtx, cancel := context.WithTimeout(ctx, cc.timeout)
+	defer cancel()
+
+	return cc.Client.RevokeStorageConsumer(apiCtx, req)
+}
@@ -24,6 +24,17 @@ const (
 	UpdateInvalidUID       MockError = "UPDATE_INVALID_UID"
 	UpdateConsumerNotFound MockError = "UPDATE_CONSUMER_NOT_FOUND"
 
+	// RotateOnboardingToken
+	RotateInternalError    MockError = "ROTATE_INTERNAL_ERROR"
+	RotateInvalidToken     MockError = "ROTATE_INVALID_TOKEN"
+	RotateConsumerNotFound MockError = "ROTATE_CONSUMER_NOT_FOUND"
+	RotateConsumerRevoked  MockError = "ROTATE_CONSUMER_REVOKED"
+
+	// RevokeStorageConsumer
+	RevokeInternalError    MockError = "REVOKE_INTERNAL_ERROR"
+	RevokeInvalidToken     MockError = "REVOKE_INVALID_TOKEN"
+	RevokeConsumerNotFound MockError = "REVOKE_CONSUMER_NOT_FOUND"
+
 	MockConsumerID = "vMHA0ppPbjg5TlgvMFcaH4QlQEJB68u+1jWQJ9O9xvde8fxz5vBuu2F6bVIY6pAYLVrC3FajrK1KxmhFTzNDow=="
 
 	MockGrantedCapacity = "2Ti"
@@ -27,6 +27,12 @@ type OCSProviderClient interface {
 	OffboardConsumer(ctx context.Context, in *OffboardConsumerRequest, opts ...grpc.CallOption) (*OffboardConsumerResponse, error)
 	// UpdateCapacity PRC call to increase or decrease the block pool size
 	UpdateCapacity(ctx context.Context, in *UpdateCapacityRequest, opts ...grpc.CallOption) (*UpdateCapacityResponse, error)
+	// RotateOnboardingToken RPC call to replace the onboarding ticket of the StorageConsumer
+	// and to rotate the keys of its ceph clients
+	RotateOnboardingToken(ctx context.Context, in *RotateOnboardingTokenRequest, opts ...grpc.CallOption) (*RotateOnboardingTokenResponse, error)
+	// RevokeStorageConsumer RPC call to revoke the access of the consumer to the storage
+	// provider cluster without deleting the StorageConsumer CR
+	RevokeStorageConsumer(ctx context.Context, in *RevokeStorageConsumerRequest, opts ...grpc.CallOption) (*RevokeStorageConsumerResponse, error)
 }
 
 type oCSProviderClient struct {
@@ -73,6 +79,24 @@ func (c *oCSProviderClient) UpdateCapacity(ctx context.Context, in *UpdateCapaci
 	return out, nil
 }
 
+func (c *oCSProviderClient) RotateOnboardingToken(ctx context.Context, in *RotateOnboardingTokenRequest, opts ...grpc.CallOption) (*RotateOnboardingTokenResponse, error) {
+	out := new(RotateOnboardingTokenResponse)
+	err := c.cc.Invoke(ctx, "/provider.OCSProvider/RotateOnboardingToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oCSProviderClient) RevokeStorageConsumer(ctx context.Context, in *RevokeStorageConsumerRequest, opts ...grpc.CallOption) (*RevokeStorageConsumerResponse, error) {
+	out := new(RevokeStorageConsumerResponse)
+	err := c.cc.Invoke(ctx, "/provider.OCSProvider/RevokeStorageConsumer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OCSProviderServer is the server API for OCSProvider service.
 // All implementations must embed UnimplementedOCSProviderServer
 // for forward compatibility
@@ -86,6 +110,12 @@ type OCSProviderServer interface {
 	OffboardConsumer(context.Context, *OffboardConsumerRequest) (*OffboardConsumerResponse, error)
 	// UpdateCapacity PRC call to increase or decrease the block pool size
 	UpdateCapacity(context.Context, *UpdateCapacityRequest) (*UpdateCapacityResponse, error)
+	// RotateOnboardingToken RPC call to replace the onboarding ticket of the StorageConsumer
+	// and to rotate the keys of its ceph clients
+	RotateOnboardingToken(context.Context, *RotateOnboardingTokenRequest) (*RotateOnboardingTokenResponse, error)
+	// RevokeStorageConsumer RPC call to revoke the access of the consumer to the storage
+	// provider cluster without deleting the StorageConsumer CR
+	RevokeStorageConsumer(context.Context, *RevokeStorageConsumerRequest) (*RevokeStorageConsumerResponse, error)
 	mustEmbedUnimplementedOCSProviderServer()
 }
 
@@ -105,6 +135,12 @@ func (UnimplementedOCSProviderServer) OffboardConsumer(context.Context, *Offboar
 func (UnimplementedOCSProviderServer) UpdateCapacity(context.Context, *UpdateCapacityRequest) (*UpdateCapacityResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateCapacity not implemented")
 }
+func (UnimplementedOCSProviderServer) RotateOnboardingToken(context.Context, *RotateOnboardingTokenRequest) (*RotateOnboardingTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateOnboardingToken not implemented")
+}
+func (UnimplementedOCSProviderServer) RevokeStorageConsumer(context.Context, *RevokeStorageConsumerRequest) (*RevokeStorageConsumerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeStorageConsumer not implemented")
+}
 func (UnimplementedOCSProviderServer) mustEmbedUnimplementedOCSProviderServer() {}
 
 // UnsafeOCSProviderServer may be embedded to opt out of forward compatibility for this service.
@@ -190,6 +226,42 @@ func _OCSProvider_UpdateCapacity_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OCSProvider_RotateOnboardingToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateOnboardingTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OCSProviderServer).RotateOnboardingToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/provider.OCSProvider/RotateOnboardingToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OCSProviderServer).RotateOnboardingToken(ctx, req.(*RotateOnboardingTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OCSProvider_RevokeStorageConsumer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeStorageConsumerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OCSProviderServer).RevokeStorageConsumer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/provider.OCSProvider/RevokeStorageConsumer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OCSProviderServer).RevokeStorageConsumer(ctx, req.(*RevokeStorageConsumerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OCSProvider_ServiceDesc is the grpc.ServiceDesc for OCSProvider service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -213,6 +285,14 @@ var OCSProvider_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateCapacity",
 			Handler:    _OCSProvider_UpdateCapacity_Handler,
 		},
+		{
+			MethodName: "RotateOnboardingToken",
+			Handler:    _OCSProvider_RotateOnboardingToken_Handler,
+		},
+		{
+			MethodName: "RevokeStorageConsumer",
+			Handler:    _OCSProvider_RevokeStorageConsumer_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "provider.proto",
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sync"
@@ -17,6 +18,7 @@ import (
 
 var (
 	errTicketAlreadyExists = errors.New("onboarding ticket already used by another storageConsumer")
+	errConsumerRevoked     = errors.New("storageConsumer access has been revoked")
 )
 
 type ocsConsumerManager struct {
@@ -43,6 +45,10 @@ func newConsumerManager(ctx context.Context, cl client.Client, namespace string)
 		if ticket, ok := consumer.GetAnnotations()[TicketAnnotation]; ok {
 			nameByTicket[ticket] = consumer.Name
 		}
+		// rotated out tickets stay blocked across restarts of the provider
+		for _, ticket := range getRetiredTickets(&consumer) {
+			nameByTicket[ticket] = consumer.Name
+		}
 	}
 
 	return &ocsConsumerManager{
@@ -153,6 +159,98 @@ func (c *ocsConsumerManager) UpdateCapacity(ctx context.Context, id string, capa
 	return nil
 }
 
+// RotateTicket replaces the onboarding ticket of the storageConsumer resource, requests new keys
+// for its ceph clients and updates the consumer cache
+func (c *ocsConsumerManager) RotateTicket(ctx context.Context, id, ticket string) error {
+	c.mutex.RLock()
+	if _, ok := c.nameByTicket[ticket]; ok {
+		c.mutex.RUnlock()
+		klog.Warning("onboarding ticket already in use")
+		return errTicketAlreadyExists
+	}
+	c.mutex.RUnlock()
+
+	// Get storage consumer resource using UID
+	consumerObj, err := c.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// the keys of a revoked consumer are not recreated, so its ticket can't be rotated
+	if consumerObj.Spec.Revoked {
+		klog.Warningf("storageConsumer %q is revoked", consumerObj.Name)
+		return errConsumerRevoked
+	}
+
+	if oldTicket, ok := consumerObj.GetAnnotations()[TicketAnnotation]; ok {
+		if err := addRetiredTicket(consumerObj, oldTicket); err != nil {
+			return err
+		}
+	}
+	annotations := consumerObj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[TicketAnnotation] = ticket
+	consumerObj.SetAnnotations(annotations)
+	consumerObj.Spec.CephClientKeysGeneration++
+
+	err = c.client.Update(ctx, consumerObj)
+	if err != nil {
+		return fmt.Errorf("failed to update storageConsumer resource %q. %v", consumerObj.Name, err)
+	}
+
+	// The replaced ticket is kept in the cache, and recorded in the RetiredTicketsAnnotation,
+	// so that it can't be used to onboard another consumer
+	c.mutex.Lock()
+	c.nameByTicket[ticket] = consumerObj.Name
+	c.mutex.Unlock()
+
+	klog.Infof("successfully rotated the onboarding ticket of the StorageConsumer resource %q", consumerObj.Name)
+
+	return nil
+}
+
+// Revoke revokes the access of the storageConsumer resource without deleting it. The revocation
+// ticket is recorded as a retired ticket of the storageConsumer, so that it can't be used again.
+func (c *ocsConsumerManager) Revoke(ctx context.Context, id, ticket string) error {
+	c.mutex.RLock()
+	if _, ok := c.nameByTicket[ticket]; ok {
+		c.mutex.RUnlock()
+		klog.Warning("revocation ticket already in use")
+		return errTicketAlreadyExists
+	}
+	c.mutex.RUnlock()
+
+	// Get storage consumer resource using UID
+	consumerObj, err := c.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if consumerObj.Spec.Revoked {
+		klog.Infof("StorageConsumer resource %q is already revoked", consumerObj.Name)
+		return nil
+	}
+
+	if err := addRetiredTicket(consumerObj, ticket); err != nil {
+		return err
+	}
+	consumerObj.Spec.Revoked = true
+	err = c.client.Update(ctx, consumerObj)
+	if err != nil {
+		return fmt.Errorf("failed to update storageConsumer resource %q. %v", consumerObj.Name, err)
+	}
+
+	c.mutex.Lock()
+	c.nameByTicket[ticket] = consumerObj.Name
+	c.mutex.Unlock()
+
+	klog.Infof("successfully revoked the StorageConsumer resource %q", consumerObj.Name)
+
+	return nil
+}
+
 // Get returns a storageConsumer resource using the UID
 func (c *ocsConsumerManager) Get(ctx context.Context, id string) (*ocsv1alpha1.StorageConsumer, error) {
 	uid := types.UID(id)
@@ -180,3 +278,32 @@ func (c *ocsConsumerManager) Get(ctx context.Context, id string) (*ocsv1alpha1.S
 
 	return consumerObj, nil
 }
+
+// getRetiredTickets returns the onboarding tickets that were rotated out of the storageConsumer
+func getRetiredTickets(consumer *ocsv1alpha1.StorageConsumer) []string {
+	data, ok := consumer.GetAnnotations()[RetiredTicketsAnnotation]
+	if !ok {
+		return nil
+	}
+	var tickets []string
+	if err := json.Unmarshal([]byte(data), &tickets); err != nil {
+		klog.Errorf("failed to unmarshal the retired onboarding tickets of storageConsumer %q. %v", consumer.Name, err)
+		return nil
+	}
+	return tickets
+}
+
+// addRetiredTicket records the ticket in the RetiredTicketsAnnotation of the storageConsumer
+func addRetiredTicket(consumer *ocsv1alpha1.StorageConsumer, ticket string) error {
+	data, err := json.Marshal(append(getRetiredTickets(consumer), ticket))
+	if err != nil {
+		return fmt.Errorf("failed to marshal the retired onboarding tickets of storageConsumer %q. %v", consumer.Name, err)
+	}
+	annotations := consumer.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[RetiredTicketsAnnotation] = string(data)
+	consumer.SetAnnotations(annotations)
+	return nil
+}
@@ -183,3 +183,91 @@ func TestGetStorageConsumer(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "consumer1", consumer.Name)
 }
+
+func TestRotateTicket(t *testing.T) {
+	ctx := context.TODO()
+	obj := []runtime.Object{}
+
+	obj = append(obj, consumer1.DeepCopy(), consumer2.DeepCopy())
+	client := newFakeClient(t, obj...)
+	consumerManager, err := newConsumerManager(ctx, client, testNamespace)
+	assert.NoError(t, err)
+
+	// Rotating the ticket using invalid UID should fail
+	err = consumerManager.RotateTicket(ctx, "invalid-uid", "ticket3")
+	assert.Error(t, err)
+
+	// Rotating to a ticket already used by another consumer should fail
+	err = consumerManager.RotateTicket(ctx, "uid1", "ticket2")
+	assert.Equal(t, errTicketAlreadyExists, err)
+
+	// Rotating the ticket using valid UID should replace the ticket and request new ceph client keys
+	err = consumerManager.RotateTicket(ctx, "uid1", "ticket3")
+	assert.NoError(t, err)
+	consumer, err := consumerManager.Get(ctx, "uid1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ticket3", consumer.Annotations[TicketAnnotation])
+	assert.Equal(t, int64(1), consumer.Spec.CephClientKeysGeneration)
+	assert.Equal(t, "consumer1", consumerManager.nameByTicket["ticket3"])
+
+	// The replaced ticket can't be used to onboard another consumer
+	_, err = consumerManager.Create(ctx, "consumer3", "ticket1", resource.MustParse("1G"))
+	assert.Equal(t, errTicketAlreadyExists, err)
+
+	// Every replaced ticket is recorded in the storageConsumer
+	err = consumerManager.RotateTicket(ctx, "uid1", "ticket4")
+	assert.NoError(t, err)
+	consumer, err = consumerManager.Get(ctx, "uid1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ticket1", "ticket3"}, getRetiredTickets(consumer))
+
+	// The replaced tickets are still rejected after the consumer cache is rebuilt
+	consumerManager, err = newConsumerManager(ctx, client, testNamespace)
+	assert.NoError(t, err)
+	for _, ticket := range []string{"ticket1", "ticket3", "ticket4"} {
+		_, err = consumerManager.Create(ctx, "consumer3", ticket, resource.MustParse("1G"))
+		assert.Equal(t, errTicketAlreadyExists, err)
+	}
+}
+
+func TestRevokeStorageConsumer(t *testing.T) {
+	ctx := context.TODO()
+	obj := []runtime.Object{}
+
+	obj = append(obj, consumer1.DeepCopy())
+	client := newFakeClient(t, obj...)
+	consumerManager, err := newConsumerManager(ctx, client, testNamespace)
+	assert.NoError(t, err)
+
+	// Revoking a consumer using invalid UID should fail
+	err = consumerManager.Revoke(ctx, "invalid-uid", "revocation-ticket1")
+	assert.Error(t, err)
+
+	// Revoking a consumer with a ticket already used by a consumer should fail
+	err = consumerManager.Revoke(ctx, "uid1", "ticket1")
+	assert.Equal(t, errTicketAlreadyExists, err)
+
+	// Revoking a consumer using valid UID should succeed and keep the consumer
+	err = consumerManager.Revoke(ctx, "uid1", "revocation-ticket1")
+	assert.NoError(t, err)
+	consumer, err := consumerManager.Get(ctx, "uid1")
+	assert.NoError(t, err)
+	assert.True(t, consumer.Spec.Revoked)
+	assert.Equal(t, []string{"revocation-ticket1"}, getRetiredTickets(consumer))
+
+	// The revocation ticket can't be used again
+	_, err = consumerManager.Create(ctx, "consumer3", "revocation-ticket1", resource.MustParse("1G"))
+	assert.Equal(t, errTicketAlreadyExists, err)
+
+	// Revoking an already revoked consumer is a no-op
+	err = consumerManager.Revoke(ctx, "uid1", "revocation-ticket2")
+	assert.NoError(t, err)
+
+	// The ticket of a revoked consumer can't be rotated
+	err = consumerManager.RotateTicket(ctx, "uid1", "ticket3")
+	assert.Equal(t, errConsumerRevoked, err)
+	consumer, err = consumerManager.Get(ctx, "uid1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ticket1", consumer.Annotations[TicketAnnotation])
+	assert.Equal(t, int64(0), consumer.Spec.CephClientKeysGeneration)
+}
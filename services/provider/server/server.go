@@ -42,6 +42,7 @@ import (
 
 const (
 	TicketAnnotation          = "ocs.openshift.io/provider-onboarding-ticket"
+	RetiredTicketsAnnotation  = "ocs.openshift.io/provider-retired-onboarding-tickets"
 	ProviderCertsMountPoint   = "/mnt/cert"
 	onboardingTicketKeySecret = "onboarding-ticket-key"
 )
@@ -140,6 +141,8 @@ func (s *OCSProviderServer) GetStorageConfig(ctx context.Context, req *pb.Storag
 		return nil, status.Errorf(codes.Unavailable, "waiting for the rook resources to be provisioned")
 	case ocsv1alpha1.StorageConsumerStateDeleting:
 		return nil, status.Errorf(codes.NotFound, "storageConsumer is already in deleting phase")
+	case ocsv1alpha1.StorageConsumerStateRevoked:
+		return nil, status.Errorf(codes.PermissionDenied, "storageConsumer access has been revoked")
 	case ocsv1alpha1.StorageConsumerStateReady:
 		conString, err := s.getExternalResources(ctx, consumerObj)
 		if err != nil {
@@ -188,6 +191,71 @@ func (s *OCSProviderServer) OffboardConsumer(ctx context.Context, req *pb.Offboa
 	return &pb.OffboardConsumerResponse{}, nil
 }
 
+// RotateOnboardingToken RPC call to replace the onboarding ticket of the StorageConsumer
+// and to rotate the keys of its ceph clients
+func (s *OCSProviderServer) RotateOnboardingToken(ctx context.Context, req *pb.RotateOnboardingTokenRequest) (*pb.RotateOnboardingTokenResponse, error) {
+	mock := os.Getenv(common.MockProviderAPI)
+	if mock != "" {
+		return mockRotateOnboardingToken(common.MockError(mock))
+	}
+
+	pubKey, err := s.getOnboardingValidationKey(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get public key to validate onboarding ticket for storageConsumer %q. %v", req.StorageConsumerUUID, err)
+	}
+
+	if err := validateTicket(req.OnboardingTicket, pubKey); err != nil {
+		klog.Errorf("failed to validate onboarding ticket for storageConsumer %q. %v", req.StorageConsumerUUID, err)
+		return nil, status.Errorf(codes.InvalidArgument, "onboarding ticket is not valid. %v", err)
+	}
+
+	if err := s.consumerManager.RotateTicket(ctx, req.StorageConsumerUUID, req.OnboardingTicket); err != nil {
+		if err == errTicketAlreadyExists {
+			return nil, status.Errorf(codes.AlreadyExists, "failed to rotate the onboarding ticket of the storageConsumer. %v", err)
+		}
+		if err == errConsumerRevoked {
+			return nil, status.Errorf(codes.FailedPrecondition, "failed to rotate the onboarding ticket of the storageConsumer. %v", err)
+		}
+		if kerrors.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "failed to rotate the onboarding ticket of the storageConsumer. %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to rotate the onboarding ticket of the storageConsumer. %v", err)
+	}
+
+	return &pb.RotateOnboardingTokenResponse{}, nil
+}
+
+// RevokeStorageConsumer RPC call to revoke the access of the consumer without deleting the StorageConsumer CR.
+// The revocation has to be authorized by a ticket signed by the provider.
+func (s *OCSProviderServer) RevokeStorageConsumer(ctx context.Context, req *pb.RevokeStorageConsumerRequest) (*pb.RevokeStorageConsumerResponse, error) {
+	mock := os.Getenv(common.MockProviderAPI)
+	if mock != "" {
+		return mockRevokeStorageConsumer(common.MockError(mock))
+	}
+
+	pubKey, err := s.getOnboardingValidationKey(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get public key to validate revocation ticket for storageConsumer %q. %v", req.StorageConsumerUUID, err)
+	}
+
+	if err := validateTicket(req.RevocationTicket, pubKey); err != nil {
+		klog.Errorf("failed to validate revocation ticket for storageConsumer %q. %v", req.StorageConsumerUUID, err)
+		return nil, status.Errorf(codes.InvalidArgument, "revocation ticket is not valid. %v", err)
+	}
+
+	if err := s.consumerManager.Revoke(ctx, req.StorageConsumerUUID, req.RevocationTicket); err != nil {
+		if err == errTicketAlreadyExists {
+			return nil, status.Errorf(codes.AlreadyExists, "failed to revoke the storageConsumer. %v", err)
+		}
+		if kerrors.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "failed to revoke the storageConsumer. %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to revoke the storageConsumer. %v", err)
+	}
+
+	return &pb.RevokeStorageConsumerResponse{}, nil
+}
+
 func (s *OCSProviderServer) Start(port int, opts []grpc.ServerOption) {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
@@ -80,3 +80,29 @@ func mockOffboardConsumer(mockError common.MockError) (*pb.OffboardConsumerRespo
 	}
 	return &pb.OffboardConsumerResponse{}, nil
 }
+
+func mockRotateOnboardingToken(mockError common.MockError) (*pb.RotateOnboardingTokenResponse, error) {
+	switch mockError {
+	case common.RotateInternalError:
+		return nil, status.Errorf(codes.Internal, "mock error message")
+	case common.RotateInvalidToken:
+		return nil, status.Errorf(codes.InvalidArgument, "mock error message")
+	case common.RotateConsumerNotFound:
+		return nil, status.Errorf(codes.NotFound, "mock error message")
+	case common.RotateConsumerRevoked:
+		return nil, status.Errorf(codes.FailedPrecondition, "mock error message")
+	}
+	return &pb.RotateOnboardingTokenResponse{}, nil
+}
+
+func mockRevokeStorageConsumer(mockError common.MockError) (*pb.RevokeStorageConsumerResponse, error) {
+	switch mockError {
+	case common.RevokeInternalError:
+		return nil, status.Errorf(codes.Internal, "mock error message")
+	case common.RevokeInvalidToken:
+		return nil, status.Errorf(codes.InvalidArgument, "mock error message")
+	case common.RevokeConsumerNotFound:
+		return nil, status.Errorf(codes.NotFound, "mock error message")
+	}
+	return &pb.RevokeStorageConsumerResponse{}, nil
+}
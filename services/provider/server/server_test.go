@@ -2,10 +2,18 @@ package server
 
 import (
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
 	ocsv1alpha1 "github.com/red-hat-storage/ocs-operator/api/v1alpha1"
 	controllers "github.com/red-hat-storage/ocs-operator/controllers/storageconsumer"
@@ -619,3 +627,67 @@ func createCephClientAndSecret(name string, server *OCSProviderServer) (*rookCep
 
 	return cephClient, secret
 }
+
+// newSignedTicket returns a ticket with the given ID signed with the key, as the provider issues them
+func newSignedTicket(t *testing.T, key *rsa.PrivateKey, id string) string {
+	message, err := json.Marshal(onboardingTicket{ID: id, ExpirationDate: time.Now().Add(time.Hour).Unix()})
+	assert.NoError(t, err)
+	hash := sha256.Sum256(message)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	assert.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(message) + "." + base64.StdEncoding.EncodeToString(signature)
+}
+
+func TestRevokeStorageConsumerTicket(t *testing.T) {
+	ctx := context.TODO()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	pubKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+	keySecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: onboardingTicketKeySecret, Namespace: testNamespace},
+		Data:       map[string][]byte{"key": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubKey})},
+	}
+
+	onboardingTicket := newSignedTicket(t, key, "onboarding")
+	consumer := consumer1.DeepCopy()
+	consumer.Annotations[TicketAnnotation] = onboardingTicket
+	client := newFakeClient(t, keySecret, consumer)
+	consumerManager, err := newConsumerManager(ctx, client, testNamespace)
+	assert.NoError(t, err)
+	server := &OCSProviderServer{
+		client:          client,
+		consumerManager: consumerManager,
+		namespace:       testNamespace,
+	}
+
+	// a ticket that is not signed by the provider is rejected
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	for _, ticket := range []string{"", "uid1", newSignedTicket(t, otherKey, "revocation")} {
+		_, err = server.RevokeStorageConsumer(ctx, &pb.RevokeStorageConsumerRequest{StorageConsumerUUID: "uid1", RevocationTicket: ticket})
+		errCode, _ := status.FromError(err)
+		assert.Equal(t, codes.InvalidArgument, errCode.Code())
+	}
+
+	// the onboarding ticket of the consumer can't be used to revoke it
+	_, err = server.RevokeStorageConsumer(ctx, &pb.RevokeStorageConsumerRequest{StorageConsumerUUID: "uid1", RevocationTicket: onboardingTicket})
+	errCode, _ := status.FromError(err)
+	assert.Equal(t, codes.AlreadyExists, errCode.Code())
+
+	revoked, err := consumerManager.Get(ctx, "uid1")
+	assert.NoError(t, err)
+	assert.False(t, revoked.Spec.Revoked)
+
+	// a new ticket signed by the provider revokes the consumer
+	_, err = server.RevokeStorageConsumer(ctx, &pb.RevokeStorageConsumerRequest{StorageConsumerUUID: "uid1", RevocationTicket: newSignedTicket(t, key, "revocation")})
+	assert.NoError(t, err)
+	revoked, err = consumerManager.Get(ctx, "uid1")
+	assert.NoError(t, err)
+	assert.True(t, revoked.Spec.Revoked)
+
+	// the ticket of a revoked consumer can't be rotated
+	_, err = server.RotateOnboardingToken(ctx, &pb.RotateOnboardingTokenRequest{StorageConsumerUUID: "uid1", OnboardingTicket: newSignedTicket(t, key, "rotation")})
+	errCode, _ = status.FromError(err)
+	assert.Equal(t, codes.FailedPrecondition, errCode.Code())
+}